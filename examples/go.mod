@@ -0,0 +1,9 @@
+module github.com/zachlatta/go-localbitcoins/examples
+
+go 1.18
+
+require github.com/zachlatta/go-localbitcoins v0.0.0
+
+require github.com/google/go-querystring v1.0.0 // indirect
+
+replace github.com/zachlatta/go-localbitcoins => ../
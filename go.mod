@@ -0,0 +1,5 @@
+module github.com/zachlatta/go-localbitcoins
+
+go 1.18
+
+require github.com/google/go-querystring v1.0.0
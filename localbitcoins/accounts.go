@@ -1,6 +1,14 @@
 package localbitcoins
 
-import "fmt"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"sync"
+	"time"
+)
 
 // AccountsService provides access to all account related functions in the
 // LocalBitcoins API.
@@ -20,18 +28,111 @@ type Account struct {
 	FeedbackCount             *int    `json:"feedback_count,omitempty"`
 	Url                       *string `json:"url,omitempty"`
 	TrustedCount              *int    `json:"trusted_count,omitempty"`
+
+	CreatedAt                      *time.Time `json:"created_at,omitempty"`
+	AgeText                        *string    `json:"age_text,omitempty"`
+	FeedbackScore                  *int       `json:"feedback_score,omitempty"`
+	HasFeedback                    *bool      `json:"has_feedback,omitempty"`
+	IdentityVerifiedAt             *time.Time `json:"identity_verified_at,omitempty"`
+	RealNameVerificationsTrusted   *int       `json:"real_name_verifications_trusted,omitempty"`
+	RealNameVerificationsUntrusted *int       `json:"real_name_verifications_untrusted,omitempty"`
+	RealNameVerificationsRejected  *int       `json:"real_name_verifications_rejected,omitempty"`
+
+	// Feedback left for the account by the authenticated account, if any.
+	MyFeedback        *FeedbackType `json:"my_feedback,omitempty"`
+	MyFeedbackMessage *string       `json:"my_feedback_msg,omitempty"`
 }
 
 func (a Account) String() string {
 	return Stringify(a)
 }
 
+// TrustScore returns a heuristic score between 0 and 100 of how trustworthy
+// the account is as a trading counterparty, where higher is more trustworthy.
+// It is computed from the account's feedback score, age, number of trading
+// partners, identity verification and real name verifications, and is reduced
+// by how often the account has been blocked. Fields that are not set count
+// neither for nor against the account. An account blocked by the
+// authenticated account always scores 0.
+//
+// The score is only meant to help rank and filter counterparties; it is not
+// computed or endorsed by LocalBitcoins.
+func (a Account) TrustScore() int {
+	return a.trustScore(time.Now())
+}
+
+func (a Account) trustScore(now time.Time) int {
+	if a.MyFeedback != nil && (*a.MyFeedback == FeedbackBlock ||
+		*a.MyFeedback == FeedbackBlockWithoutFeedback) {
+		return 0
+	}
+
+	var score float64
+
+	// feedback score, worth up to 40 points
+	if a.FeedbackScore != nil && a.FeedbackCount != nil && *a.FeedbackCount > 0 {
+		score += 40 * clamp(float64(*a.FeedbackScore)/100)
+	}
+
+	// account age, worth up to 20 points for accounts two years or older
+	if a.CreatedAt != nil {
+		const twoYears = 2 * 365 * 24 * time.Hour
+		score += 20 * clamp(float64(now.Sub(*a.CreatedAt))/float64(twoYears))
+	}
+
+	// trading partners, worth up to 20 points for 100 partners or more
+	if a.TradingPartnersCount != nil {
+		n := float64(*a.TradingPartnersCount)
+		score += 20 * clamp(math.Log10(1+n)/math.Log10(101))
+	}
+
+	// identity verification, worth 10 points
+	if a.IdentityVerifiedAt != nil && !a.IdentityVerifiedAt.IsZero() {
+		score += 10
+	}
+
+	// real name verifications, worth up to 10 points, with rejections
+	// outweighing trusted verifications
+	if a.RealNameVerificationsTrusted != nil || a.RealNameVerificationsRejected != nil {
+		var trusted, rejected int
+		if a.RealNameVerificationsTrusted != nil {
+			trusted = *a.RealNameVerificationsTrusted
+		}
+		if a.RealNameVerificationsRejected != nil {
+			rejected = *a.RealNameVerificationsRejected
+		}
+		score += 10 * clamp(float64(trusted-2*rejected)/5)
+	}
+
+	// blocks, scaling the score down by the share of partners that blocked the
+	// account
+	if a.BlockedCount != nil && *a.BlockedCount > 0 {
+		partners := *a.BlockedCount
+		if a.TradingPartnersCount != nil && *a.TradingPartnersCount > partners {
+			partners = *a.TradingPartnersCount
+		}
+		score *= 1 - float64(*a.BlockedCount)/float64(partners)
+	}
+
+	return int(math.Round(score))
+}
+
+// Returns v limited to the range [0, 1].
+func clamp(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
+
 // Get fetches an account. Passing an empty string will fetch the authenticated
 // account.
 func (s *AccountsService) Get(account string) (*Account, *Response, error) {
+	return s.GetContext(context.Background(), account)
+}
+
+// GetContext is like Get but uses ctx to control its requests.
+func (s *AccountsService) GetContext(ctx context.Context, account string) (*Account, *Response, error) {
 	var a string
 	if account != "" {
-		a = fmt.Sprintf("api/account_info/%v/", account)
+		a = fmt.Sprintf("api/account_info/%v/", url.PathEscape(account))
 	} else {
 		a = "api/myself/"
 	}
@@ -42,10 +143,74 @@ func (s *AccountsService) Get(account string) (*Account, *Response, error) {
 
 	acc := new(Account)
 	aResp := &ResponseData{Data: acc}
-	resp, err := s.client.Do(req, aResp)
+	resp, err := s.client.DoContext(ctx, req, aResp)
 	if err != nil {
 		return nil, resp, err
 	}
 
 	return acc, resp, err
 }
+
+// Maximum number of accounts fetched concurrently by GetMany.
+const getManyWorkers = 4
+
+// ErrEmptyUsername is reported by GetMany for empty usernames, which it does
+// not fetch, as Get would return the authenticated account for them.
+var ErrEmptyUsername = errors.New("localbitcoins: empty username")
+
+// AccountResult is the result of fetching a single account with
+// AccountsService.GetMany.
+type AccountResult struct {
+	Username string
+	Account  *Account
+	Response *Response
+	Err      error
+}
+
+// GetMany fetches the accounts with the provided usernames concurrently, using
+// a bounded number of requests at a time. Requests still wait on the client's
+// RateLimiter, if any. The results are returned in the order of usernames,
+// along with the first error among them, if any. Empty usernames are reported
+// with ErrEmptyUsername.
+func (s *AccountsService) GetMany(usernames ...string) ([]*AccountResult, error) {
+	return s.GetManyContext(context.Background(), usernames...)
+}
+
+// GetManyContext is like GetMany but uses ctx to control its requests.
+// Accounts not yet fetched when ctx is done are reported with the error of
+// ctx.
+func (s *AccountsService) GetManyContext(ctx context.Context, usernames ...string) ([]*AccountResult, error) {
+	results := make([]*AccountResult, len(usernames))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < getManyWorkers && w < len(usernames); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				r := &AccountResult{Username: usernames[i]}
+				if err := ctx.Err(); err != nil {
+					r.Err = err
+				} else if usernames[i] == "" {
+					r.Err = ErrEmptyUsername
+				} else {
+					r.Account, r.Response, r.Err = s.GetContext(ctx, usernames[i])
+				}
+				results[i] = r
+			}
+		}()
+	}
+	for i := range usernames {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, r := range results {
+		if r.Err != nil {
+			return results, r.Err
+		}
+	}
+	return results, nil
+}
@@ -1,10 +1,16 @@
 package localbitcoins
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestAccount_marshall(t *testing.T) {
@@ -76,3 +82,238 @@ func TestAccountsService_Get_authenticatedUser(t *testing.T) {
 		t.Errorf("Accounts.Get returned %+v, want %+v", acc, want)
 	}
 }
+
+func TestAccountsService_GetContext_canceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/myself/", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	acc, _, err := client.Accounts.GetContext(ctx, "")
+	if err != context.Canceled {
+		t.Errorf("Accounts.GetContext returned error %v, want %v", err, context.Canceled)
+	}
+	if acc != nil {
+		t.Errorf("Accounts.GetContext returned %+v, want nil", acc)
+	}
+}
+
+func TestAccount_unmarshalAccountInfo(t *testing.T) {
+	data := []byte(`{
+    "username": "foo",
+    "created_at": "2013-06-25T14:36:25+00:00",
+    "age_text": "2 years",
+    "feedback_score": 98,
+    "has_feedback": true,
+    "identity_verified_at": "2014-01-02T03:04:05+00:00",
+    "real_name_verifications_trusted": 3,
+    "real_name_verifications_untrusted": 1,
+    "real_name_verifications_rejected": 0,
+    "trading_partners_count": 12,
+    "my_feedback": "trust",
+    "my_feedback_msg": "good"
+  }`)
+
+	a := new(Account)
+	if err := json.Unmarshal(data, a); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+
+	ft := FeedbackTrust
+	want := &Account{
+		Username:                       String("foo"),
+		CreatedAt:                      timePtr(time.Date(2013, 6, 25, 14, 36, 25, 0, time.UTC)),
+		AgeText:                        String("2 years"),
+		FeedbackScore:                  Int(98),
+		HasFeedback:                    Bool(true),
+		IdentityVerifiedAt:             timePtr(time.Date(2014, 1, 2, 3, 4, 5, 0, time.UTC)),
+		RealNameVerificationsTrusted:   Int(3),
+		RealNameVerificationsUntrusted: Int(1),
+		RealNameVerificationsRejected:  Int(0),
+		TradingPartnersCount:           Int(12),
+		MyFeedback:                     &ft,
+		MyFeedbackMessage:              String("good"),
+	}
+	if !a.CreatedAt.Equal(*want.CreatedAt) ||
+		!a.IdentityVerifiedAt.Equal(*want.IdentityVerifiedAt) {
+		t.Errorf("json.Unmarshal returned times %v and %v, want %v and %v",
+			a.CreatedAt, a.IdentityVerifiedAt, want.CreatedAt,
+			want.IdentityVerifiedAt)
+	}
+	a.CreatedAt, want.CreatedAt = nil, nil
+	a.IdentityVerifiedAt, want.IdentityVerifiedAt = nil, nil
+	if !reflect.DeepEqual(a, want) {
+		t.Errorf("json.Unmarshal returned %+v, want %+v", a, want)
+	}
+}
+
+func TestAccount_trustScore(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	block := FeedbackBlock
+
+	tests := []struct {
+		name    string
+		account Account
+		want    int
+	}{
+		{"unknown", Account{}, 0},
+		{"established", Account{
+			FeedbackScore:                Int(100),
+			FeedbackCount:                Int(250),
+			CreatedAt:                    timePtr(now.AddDate(-3, 0, 0)),
+			TradingPartnersCount:         Int(100),
+			IdentityVerifiedAt:           timePtr(now.AddDate(-2, 0, 0)),
+			RealNameVerificationsTrusted: Int(5),
+		}, 100},
+		{"new", Account{
+			CreatedAt:            timePtr(now.AddDate(-1, 0, 0)),
+			TradingPartnersCount: Int(0),
+		}, 10},
+		{"feedback without count", Account{FeedbackScore: Int(100)}, 0},
+		{"rejected real names", Account{
+			FeedbackScore:                 Int(100),
+			FeedbackCount:                 Int(1),
+			RealNameVerificationsTrusted:  Int(1),
+			RealNameVerificationsRejected: Int(1),
+		}, 40},
+		{"blocked by half of partners", Account{
+			FeedbackScore:        Int(100),
+			FeedbackCount:        Int(10),
+			TradingPartnersCount: Int(10),
+			BlockedCount:         Int(5),
+		}, 20 + 5},
+		{"blocked by us", Account{
+			FeedbackScore: Int(100),
+			FeedbackCount: Int(10),
+			MyFeedback:    &block,
+		}, 0},
+	}
+
+	for _, tt := range tests {
+		if got := tt.account.trustScore(now); got != tt.want {
+			t.Errorf("%v: trustScore returned %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func TestAccountsService_GetMany(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	var active, maxActive int
+	mux.HandleFunc("/api/account_info/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		mu.Lock()
+		active++
+		if active > maxActive {
+			maxActive = active
+		}
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			active--
+			mu.Unlock()
+		}()
+		time.Sleep(10 * time.Millisecond)
+
+		username := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/account_info/"), "/")
+		if username == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":{"message":"Not found","error_code":12}}`)
+			return
+		}
+		fmt.Fprintf(w, `{"data":{"username":%q}}`, username)
+	})
+
+	usernames := []string{"a", "b", "missing", "c", "d", "e", "f", "g"}
+	results, err := client.Accounts.GetMany(usernames...)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Accounts.GetMany returned error %v, want %v", err, ErrNotFound)
+	}
+
+	if len(results) != len(usernames) {
+		t.Fatalf("Accounts.GetMany returned %v results, want %v", len(results),
+			len(usernames))
+	}
+	for i, r := range results {
+		if r.Username != usernames[i] {
+			t.Errorf("results[%v].Username = %v, want %v", i, r.Username, usernames[i])
+		}
+		if usernames[i] == "missing" {
+			if r.Err == nil || r.Account != nil {
+				t.Errorf("results[%v] = %+v, want an error", i, r)
+			}
+			continue
+		}
+		if r.Err != nil {
+			t.Errorf("results[%v].Err = %v, want nil", i, r.Err)
+		}
+		if r.Account == nil || *r.Account.Username != usernames[i] {
+			t.Errorf("results[%v].Account = %+v, want %v", i, r.Account, usernames[i])
+		}
+	}
+
+	if maxActive > getManyWorkers {
+		t.Errorf("Accounts.GetMany made %v concurrent requests, want at most %v",
+			maxActive, getManyWorkers)
+	}
+}
+
+func TestAccountsService_GetMany_emptyUsername(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/myself/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request to %v", r.URL)
+	})
+	mux.HandleFunc("/api/account_info/", func(w http.ResponseWriter, r *http.Request) {
+		if want := "/api/account_info/a%2Fb/"; r.URL.EscapedPath() != want {
+			t.Errorf("Request path: %v, want %v", r.URL.EscapedPath(), want)
+		}
+		fmt.Fprint(w, `{"data":{"username":"a/b"}}`)
+	})
+
+	results, err := client.Accounts.GetMany("a/b", "")
+	if err != ErrEmptyUsername {
+		t.Errorf("Accounts.GetMany returned error %v, want %v", err, ErrEmptyUsername)
+	}
+	if r := results[0]; r.Err != nil || r.Account == nil {
+		t.Errorf("results[0] = %+v, want an account", r)
+	}
+	if r := results[1]; r.Err != ErrEmptyUsername || r.Account != nil {
+		t.Errorf("results[1] = %+v, want %v", r, ErrEmptyUsername)
+	}
+}
+
+func TestAccountsService_GetManyContext_canceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/account_info/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request to %v", r.URL)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, err := client.Accounts.GetManyContext(ctx, "a", "b")
+	if err != context.Canceled {
+		t.Errorf("Accounts.GetManyContext returned error %v, want %v", err, context.Canceled)
+	}
+	for i, r := range results {
+		if r.Err != context.Canceled {
+			t.Errorf("results[%v].Err = %v, want %v", i, r.Err, context.Canceled)
+		}
+	}
+}
@@ -0,0 +1,398 @@
+package localbitcoins
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/zachlatta/go-localbitcoins/localbitcoins/equation"
+)
+
+// AdsService handles all advertisement-related communications with the
+// LocalBitcoins API.
+type AdsService struct {
+	client *Client
+}
+
+// TradeType is the type of trade an advertisement offers.
+type TradeType string
+
+// Trade types supported by the LocalBitcoins API.
+const (
+	LocalSell  TradeType = "LOCAL_SELL"
+	LocalBuy   TradeType = "LOCAL_BUY"
+	OnlineSell TradeType = "ONLINE_SELL"
+	OnlineBuy  TradeType = "ONLINE_BUY"
+)
+
+// Ad represents an advertisement as returned by the LocalBitcoins API.
+type Ad struct {
+	ID                         *int       `json:"ad_id,omitempty"`
+	CreatedAt                  *time.Time `json:"created_at,omitempty"`
+	Visible                    *bool      `json:"visible,omitempty"`
+	TradeType                  *TradeType `json:"trade_type,omitempty"`
+	PriceEquation              *string    `json:"price_equation,omitempty"`
+	TempPrice                  *Amount    `json:"temp_price,omitempty"`
+	TempPriceUSD               *Amount    `json:"temp_price_usd,omitempty"`
+	MinAmount                  *Amount    `json:"min_amount,omitempty"`
+	MaxAmount                  *Amount    `json:"max_amount,omitempty"`
+	MaxAmountAvailable         *Amount    `json:"max_amount_available,omitempty"`
+	Currency                   *string    `json:"currency,omitempty"`
+	CountryCode                *string    `json:"countrycode,omitempty"`
+	City                       *string    `json:"city,omitempty"`
+	LocationString             *string    `json:"location_string,omitempty"`
+	Lat                        *float64   `json:"lat,omitempty"`
+	Lon                        *float64   `json:"lon,omitempty"`
+	OnlineProvider             *string    `json:"online_provider,omitempty"`
+	AccountInfo                *string    `json:"account_info,omitempty"`
+	BankName                   *string    `json:"bank_name,omitempty"`
+	Message                    *string    `json:"msg,omitempty"`
+	ATMModel                   *string    `json:"atm_model,omitempty"`
+	RequireTradeVolume         *Amount    `json:"require_trade_volume,omitempty"`
+	RequireFeedbackScore       *int       `json:"require_feedback_score,omitempty"`
+	RequireTrustedByAdvertiser *bool      `json:"require_trusted_by_advertiser,omitempty"`
+	RequireIdentification      *bool      `json:"require_identification,omitempty"`
+	SMSVerificationRequired    *bool      `json:"sms_verification_required,omitempty"`
+	TrustedRequired            *bool      `json:"trusted_required,omitempty"`
+	TrackMaxAmount             *bool      `json:"track_max_amount,omitempty"`
+	IsLocalOffice              *bool      `json:"is_local_office,omitempty"`
+	HiddenByOpeningHours       *bool      `json:"hidden_by_opening_hours,omitempty"`
+	PaymentWindowMinutes       *int       `json:"payment_window_minutes,omitempty"`
+	Profile                    *Profile   `json:"profile,omitempty"`
+
+	// Actions holds the URLs of actions available for the advertisement. It is
+	// populated from the actions object that accompanies the advertisement.
+	Actions *AdActions `json:"-"`
+}
+
+func (a Ad) String() string {
+	return Stringify(a)
+}
+
+// PreviewPrice computes the price per bitcoin of the advertisement locally, as
+// EvalPriceEquation does with its price equation.
+func (a Ad) PreviewPrice(rates equation.Rates) (Amount, error) {
+	if a.PriceEquation == nil {
+		return Amount{}, fmt.Errorf("localbitcoins: ad has no price equation")
+	}
+	return EvalPriceEquation(*a.PriceEquation, rates)
+}
+
+// AdActions holds the URLs of actions available for an advertisement.
+type AdActions struct {
+	PublicView  *string `json:"public_view,omitempty"`
+	HTMLForm    *string `json:"html_form,omitempty"`
+	ChangeForm  *string `json:"change_form,omitempty"`
+	ContactForm *string `json:"contact_form,omitempty"`
+}
+
+func (a AdActions) String() string {
+	return Stringify(a)
+}
+
+// Profile is the summary of a user's account that accompanies advertisements
+// and trades.
+type Profile struct {
+	Username      *string    `json:"username,omitempty"`
+	Name          *string    `json:"name,omitempty"`
+	TradeCount    *string    `json:"trade_count,omitempty"`
+	FeedbackScore *int       `json:"feedback_score,omitempty"`
+	LastOnline    *time.Time `json:"last_online,omitempty"`
+}
+
+func (p Profile) String() string {
+	return Stringify(p)
+}
+
+// AdListOptions specifies the optional parameters to the AdsService.List
+// method.
+type AdListOptions struct {
+	Visible     *bool     `url:"visible,omitempty"`
+	TradeType   TradeType `url:"trade_type,omitempty"`
+	Currency    string    `url:"currency,omitempty"`
+	CountryCode string    `url:"countrycode,omitempty"`
+}
+
+// AdOptions specifies the parameters to the AdsService.Create and
+// AdsService.Update methods.
+type AdOptions struct {
+	PriceEquation              string      `url:"price_equation,omitempty"`
+	Lat                        *Coordinate `url:"lat,omitempty"`
+	Lon                        *Coordinate `url:"lon,omitempty"`
+	City                       string      `url:"city,omitempty"`
+	LocationString             string      `url:"location_string,omitempty"`
+	CountryCode                string      `url:"countrycode,omitempty"`
+	Currency                   string      `url:"currency,omitempty"`
+	AccountInfo                string      `url:"account_info,omitempty"`
+	BankName                   string      `url:"bank_name,omitempty"`
+	Message                    string      `url:"msg,omitempty"`
+	SMSVerificationRequired    *bool       `url:"sms_verification_required,omitempty"`
+	TrackMaxAmount             *bool       `url:"track_max_amount,omitempty"`
+	RequireTrustedByAdvertiser *bool       `url:"require_trusted_by_advertiser,omitempty"`
+	RequireIdentification      *bool       `url:"require_identification,omitempty"`
+	OnlineProvider             string      `url:"online_provider,omitempty"`
+	TradeType                  TradeType   `url:"trade_type,omitempty"`
+	MinAmount                  *Amount     `url:"min_amount,omitempty"`
+	MaxAmount                  *Amount     `url:"max_amount,omitempty"`
+	OpeningHours               string      `url:"opening_hours,omitempty"`
+	Visible                    *bool       `url:"visible,omitempty"`
+}
+
+// Coordinate is a latitude or longitude in degrees.
+type Coordinate float64
+
+// Coord is a helper function that allocates a new Coordinate value to store v
+// and returns a pointer to it.
+func Coord(v float64) *Coordinate {
+	p := new(Coordinate)
+	*p = Coordinate(v)
+	return p
+}
+
+// EncodeValues encodes c as a URL query or form parameter in decimal notation,
+// as the API doesn't accept exponents. It implements the query.Encoder
+// interface.
+func (c Coordinate) EncodeValues(key string, v *url.Values) error {
+	v.Set(key, strconv.FormatFloat(float64(c), 'f', -1, 64))
+	return nil
+}
+
+// PreviewPrice computes the price per bitcoin of an advertisement created or
+// updated with these options locally, as EvalPriceEquation does with
+// PriceEquation. It allows checking the price before calling the API.
+func (o AdOptions) PreviewPrice(rates equation.Rates) (Amount, error) {
+	return EvalPriceEquation(o.PriceEquation, rates)
+}
+
+// EvalPriceEquation parses and evaluates the price equation eq against rates,
+// and returns the resulting price rounded to two decimal places, like the
+// prices returned by the LocalBitcoins API. A *equation.SyntaxError or
+// *equation.EvalError is returned if eq is invalid or cannot be evaluated.
+func EvalPriceEquation(eq string, rates equation.Rates) (Amount, error) {
+	e, err := equation.Parse(eq)
+	if err != nil {
+		return Amount{}, err
+	}
+	v, err := e.Eval(rates)
+	if err != nil {
+		return Amount{}, err
+	}
+	return ParseAmount(v.FloatString(2))
+}
+
+// Ad list middleman used strictly for unmarshaling the API response.
+type adListMiddleman struct {
+	Ads []*adMiddleman `json:"ad_list,omitempty"`
+}
+
+// Middleman used strictly for unmarshaling individual advertisements.
+type adMiddleman struct {
+	Ad      *Ad        `json:"data,omitempty"`
+	Actions *AdActions `json:"actions,omitempty"`
+}
+
+// Returns the advertisements held by the middleman, with their actions
+// attached.
+func (m *adListMiddleman) ads() []*Ad {
+	ads := make([]*Ad, len(m.Ads))
+	for i, a := range m.Ads {
+		ads[i] = a.Ad
+		if ads[i] == nil {
+			ads[i] = new(Ad)
+		}
+		ads[i].Actions = a.Actions
+	}
+	return ads
+}
+
+// Middleman used strictly for unmarshaling the result of modifying an
+// advertisement.
+type adResultMiddleman struct {
+	Message *string `json:"message,omitempty"`
+	ID      *int    `json:"ad_id,omitempty"`
+}
+
+// List lists the advertisements of the authenticated account.
+func (s *AdsService) List(opt *AdListOptions) ([]*Ad, *Response, error) {
+	return s.ListContext(context.Background(), opt)
+}
+
+// ListContext is like List but uses ctx to control its requests.
+func (s *AdsService) ListContext(ctx context.Context, opt *AdListOptions) ([]*Ad, *Response, error) {
+	u, err := addOptions("api/ads/", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.listAds(ctx, u)
+}
+
+// ListIterator returns an Iterator over the advertisements of the
+// authenticated account, fetching every page of the list.
+func (s *AdsService) ListIterator(opt *AdListOptions) *Iterator[*Ad] {
+	u, err := addOptions("api/ads/", opt)
+	if err != nil {
+		return &Iterator[*Ad]{err: err}
+	}
+
+	return NewIterator(u, s.listAds)
+}
+
+// Get fetches an advertisement by its ID.
+func (s *AdsService) Get(id int) (*Ad, *Response, error) {
+	return s.GetContext(context.Background(), id)
+}
+
+// GetContext is like Get but uses ctx to control its requests.
+func (s *AdsService) GetContext(ctx context.Context, id int) (*Ad, *Response, error) {
+	u := fmt.Sprintf("api/ad-get/%v/", id)
+	ads, resp, err := s.listAds(ctx, u)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if len(ads) == 0 {
+		return nil, resp, fmt.Errorf("localbitcoins: ad %v not found", id)
+	}
+	return ads[0], resp, err
+}
+
+func (s *AdsService) listAds(ctx context.Context, u string) ([]*Ad, *Response, error) {
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	middleman := new(adListMiddleman)
+	respMiddleman := &ResponseData{Data: middleman}
+	resp, err := s.client.DoContext(ctx, req, respMiddleman)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return middleman.ads(), resp, err
+}
+
+// Create creates a new advertisement for the authenticated account and returns
+// the ID of the created advertisement.
+func (s *AdsService) Create(opt *AdOptions) (int, *Response, error) {
+	return s.CreateContext(context.Background(), opt)
+}
+
+// CreateContext is like Create but uses ctx to control its requests.
+func (s *AdsService) CreateContext(ctx context.Context, opt *AdOptions) (int, *Response, error) {
+	result, resp, err := s.post(ctx, "api/ad-create/", opt)
+	if err != nil {
+		return 0, resp, err
+	}
+
+	var id int
+	if result.ID != nil {
+		id = *result.ID
+	}
+	return id, resp, err
+}
+
+// Update updates the advertisement with the provided ID. All parameters of the
+// advertisement should be provided, as omitted ones may be reset by the API.
+func (s *AdsService) Update(id int, opt *AdOptions) (*Response, error) {
+	return s.UpdateContext(context.Background(), id, opt)
+}
+
+// UpdateContext is like Update but uses ctx to control its requests.
+func (s *AdsService) UpdateContext(ctx context.Context, id int, opt *AdOptions) (*Response, error) {
+	_, resp, err := s.post(ctx, fmt.Sprintf("api/ad/%v/", id), opt)
+	return resp, err
+}
+
+// Delete deletes the advertisement with the provided ID.
+func (s *AdsService) Delete(id int) (*Response, error) {
+	return s.DeleteContext(context.Background(), id)
+}
+
+// DeleteContext is like Delete but uses ctx to control its requests.
+func (s *AdsService) DeleteContext(ctx context.Context, id int) (*Response, error) {
+	_, resp, err := s.post(ctx, fmt.Sprintf("api/ad-delete/%v/", id), nil)
+	return resp, err
+}
+
+// Options of the equation endpoints of the LocalBitcoins API.
+type equationOptions struct {
+	PriceEquation string `url:"price_equation"`
+	Currency      string `url:"currency,omitempty"`
+}
+
+// ValidateEquation has the LocalBitcoins API evaluate the price equation eq in
+// the provided currency, and returns the resulting price per bitcoin. An
+// invalid equation is reported as an API error. See EvalPriceEquation for
+// evaluating equations locally.
+func (s *AdsService) ValidateEquation(eq, currency string) (Amount, *Response, error) {
+	return s.ValidateEquationContext(context.Background(), eq, currency)
+}
+
+// ValidateEquationContext is like ValidateEquation but uses ctx to control its
+// requests.
+func (s *AdsService) ValidateEquationContext(ctx context.Context, eq, currency string) (Amount, *Response, error) {
+	opt := &equationOptions{PriceEquation: eq, Currency: currency}
+	req, err := s.client.NewFormRequest("POST", "api/equation/", opt)
+	if err != nil {
+		return Amount{}, nil, err
+	}
+
+	var price Amount
+	respMiddleman := &ResponseData{Data: &price}
+	resp, err := s.client.DoContext(ctx, req, respMiddleman)
+	if err != nil {
+		return Amount{}, resp, err
+	}
+
+	return price, resp, err
+}
+
+// UpdateEquation changes only the price equation of the advertisement with the
+// provided ID, leaving its other parameters as they are. The message returned
+// by the API is passed back to the caller.
+func (s *AdsService) UpdateEquation(id int, eq string) (string, *Response, error) {
+	return s.UpdateEquationContext(context.Background(), id, eq)
+}
+
+// UpdateEquationContext is like UpdateEquation but uses ctx to control its
+// requests.
+func (s *AdsService) UpdateEquationContext(ctx context.Context, id int, eq string) (string, *Response, error) {
+	u := fmt.Sprintf("api/ad-equation/%v/", id)
+	req, err := s.client.NewFormRequest("POST", u, &equationOptions{PriceEquation: eq})
+	if err != nil {
+		return "", nil, err
+	}
+
+	result := new(adResultMiddleman)
+	respMiddleman := &ResponseData{Data: result}
+	resp, err := s.client.DoContext(ctx, req, respMiddleman)
+	if err != nil {
+		return "", resp, err
+	}
+
+	var msg string
+	if result.Message != nil {
+		msg = *result.Message
+	}
+	return msg, resp, err
+}
+
+func (s *AdsService) post(ctx context.Context, u string, opt *AdOptions) (*adResultMiddleman, *Response, error) {
+	req, err := s.client.NewFormRequest("POST", u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := new(adResultMiddleman)
+	respMiddleman := &ResponseData{Data: result}
+	resp, err := s.client.DoContext(ctx, req, respMiddleman)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return result, resp, err
+}
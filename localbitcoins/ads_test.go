@@ -0,0 +1,300 @@
+package localbitcoins
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/zachlatta/go-localbitcoins/localbitcoins/equation"
+)
+
+func TestAd_marshall(t *testing.T) {
+	testJSONMarshal(t, &Ad{}, "{}")
+
+	tt := OnlineSell
+	a := &Ad{
+		ID:             Int(1),
+		Visible:        Bool(true),
+		TradeType:      &tt,
+		PriceEquation:  String("btc_in_usd*1.02"),
+		MinAmount:      amountPtr("10"),
+		MaxAmount:      amountPtr("1000.5"),
+		Currency:       String("USD"),
+		OnlineProvider: String("NATIONAL_BANK"),
+		Profile:        &Profile{Username: String("foo")},
+	}
+	want := `{
+    "ad_id": 1,
+    "visible": true,
+    "trade_type": "ONLINE_SELL",
+    "price_equation": "btc_in_usd*1.02",
+    "min_amount": "10",
+    "max_amount": "1000.5",
+    "currency": "USD",
+    "online_provider": "NATIONAL_BANK",
+    "profile": {"username": "foo"}
+  }`
+	testJSONMarshal(t, a, want)
+}
+
+func TestAdsService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/ads/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got, want := r.URL.Query().Get("trade_type"), "ONLINE_BUY"; got != want {
+			t.Errorf("trade_type = %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `{
+      "data":{
+        "ad_list":[
+        {
+          "data":{"ad_id":1,"trade_type":"ONLINE_BUY"},
+          "actions":{"public_view":"bar"}
+        }
+        ],
+        "ad_count":1
+      }
+    }`)
+	})
+
+	ads, _, err := client.Ads.List(&AdListOptions{TradeType: OnlineBuy})
+	if err != nil {
+		t.Errorf("Ads.List returned error: %v", err)
+	}
+
+	tt := OnlineBuy
+	want := []*Ad{
+		&Ad{ID: Int(1), TradeType: &tt, Actions: &AdActions{PublicView: String("bar")}},
+	}
+	if !reflect.DeepEqual(ads, want) {
+		t.Errorf("Ads.List returned %+v, want %+v", ads, want)
+	}
+}
+
+func TestAdsService_Get(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/ad-get/1/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data":{"ad_list":[{"data":{"ad_id":1}}],"ad_count":1}}`)
+	})
+
+	ad, _, err := client.Ads.Get(1)
+	if err != nil {
+		t.Errorf("Ads.Get returned error: %v", err)
+	}
+
+	want := &Ad{ID: Int(1)}
+	if !reflect.DeepEqual(ad, want) {
+		t.Errorf("Ads.Get returned %+v, want %+v", ad, want)
+	}
+}
+
+func TestAdsService_Get_notFound(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/ad-get/1/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"ad_list":[],"ad_count":0}}`)
+	})
+
+	_, _, err := client.Ads.Get(1)
+	if err == nil {
+		t.Error("Expected error to be returned.")
+	}
+}
+
+func TestAdsService_Create(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/ad-create/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testFormValues(t, r, values{
+			"price_equation": "btc_in_usd",
+			"trade_type":     "ONLINE_SELL",
+			"visible":        "false",
+		})
+		fmt.Fprint(w, `{"data":{"message":"Ad added","ad_id":7}}`)
+	})
+
+	opt := &AdOptions{
+		PriceEquation: "btc_in_usd",
+		TradeType:     OnlineSell,
+		Visible:       Bool(false),
+	}
+	id, _, err := client.Ads.Create(opt)
+	if err != nil {
+		t.Errorf("Ads.Create returned error: %v", err)
+	}
+
+	if want := 7; id != want {
+		t.Errorf("Ads.Create returned %v, want %v", id, want)
+	}
+}
+
+func TestAdsService_Update(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/ad/7/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testFormValues(t, r, values{
+			"lat":      "0.00001",
+			"lon":      "2",
+			"currency": "EUR",
+		})
+		fmt.Fprint(w, `{"data":{"message":"Ad edited successfully!"}}`)
+	})
+
+	_, err := client.Ads.Update(7, &AdOptions{Lat: Coord(0.00001), Lon: Coord(2), Currency: "EUR"})
+	if err != nil {
+		t.Errorf("Ads.Update returned error: %v", err)
+	}
+}
+
+func TestAdsService_Update_online(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/ad/7/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testFormValues(t, r, values{
+			"price_equation":  "btc_in_usd*1.02",
+			"online_provider": "NATIONAL_BANK",
+		})
+		fmt.Fprint(w, `{"data":{"message":"Ad edited successfully!"}}`)
+	})
+
+	_, err := client.Ads.Update(7, &AdOptions{
+		PriceEquation:  "btc_in_usd*1.02",
+		OnlineProvider: "NATIONAL_BANK",
+	})
+	if err != nil {
+		t.Errorf("Ads.Update returned error: %v", err)
+	}
+}
+
+func TestAdsService_Delete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/ad-delete/7/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, `{"data":{"message":"Success!"}}`)
+	})
+
+	_, err := client.Ads.Delete(7)
+	if err != nil {
+		t.Errorf("Ads.Delete returned error: %v", err)
+	}
+}
+
+func TestAd_PreviewPrice(t *testing.T) {
+	rates := equation.RateTable{}
+	rates.Set("btc_in_usd", "10000")
+	rates.Set("USD_in_EUR", "0.9")
+
+	ad := Ad{PriceEquation: String("btc_in_usd*USD_in_EUR*1.03")}
+	price, err := ad.PreviewPrice(rates)
+	if err != nil {
+		t.Fatalf("PreviewPrice returned error: %v", err)
+	}
+	if want := MustParseAmount("9270.00"); price != want {
+		t.Errorf("PreviewPrice returned %v, want %v", price, want)
+	}
+
+	if _, err := (Ad{}).PreviewPrice(rates); err == nil {
+		t.Errorf("PreviewPrice without an equation returned no error")
+	}
+}
+
+func TestAdOptions_PreviewPrice(t *testing.T) {
+	rates := equation.RateTable{}
+	rates.Set("btc_in_usd", "3")
+
+	opt := AdOptions{PriceEquation: "btc_in_usd/7"}
+	price, err := opt.PreviewPrice(rates)
+	if err != nil {
+		t.Fatalf("PreviewPrice returned error: %v", err)
+	}
+	if want := MustParseAmount("0.43"); price != want {
+		t.Errorf("PreviewPrice returned %v, want %v", price, want)
+	}
+}
+
+func TestEvalPriceEquation_errors(t *testing.T) {
+	_, err := EvalPriceEquation("btc_in_usd*", equation.RateTable{})
+	if _, ok := err.(*equation.SyntaxError); !ok {
+		t.Errorf("EvalPriceEquation returned %#v, want a *equation.SyntaxError", err)
+	}
+
+	_, err = EvalPriceEquation("btc_in_usd", equation.RateTable{})
+	if _, ok := err.(*equation.EvalError); !ok {
+		t.Errorf("EvalPriceEquation returned %#v, want a *equation.EvalError", err)
+	}
+}
+
+func TestAdsService_ValidateEquation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/equation/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testFormValues(t, r, values{
+			"price_equation": "btc_in_usd*USD_in_EUR*1.03",
+			"currency":       "EUR",
+		})
+		fmt.Fprint(w, `{"data":"9270.46"}`)
+	})
+
+	price, _, err := client.Ads.ValidateEquation("btc_in_usd*USD_in_EUR*1.03", "EUR")
+	if err != nil {
+		t.Errorf("Ads.ValidateEquation returned error: %v", err)
+	}
+
+	if want := MustParseAmount("9270.46"); price != want {
+		t.Errorf("Ads.ValidateEquation returned %v, want %v", price, want)
+	}
+}
+
+func TestAdsService_ValidateEquation_invalid(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/equation/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error":{"message":"Invalid equation","error_code":9}}`)
+	})
+
+	_, _, err := client.Ads.ValidateEquation("btc_in_usd*", "")
+	if !errors.Is(err, ErrInvalidParameters) {
+		t.Errorf("Ads.ValidateEquation returned error %v, want %v", err,
+			ErrInvalidParameters)
+	}
+}
+
+func TestAdsService_UpdateEquation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/ad-equation/1/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testFormValues(t, r, values{"price_equation": "bitstampusd*1.02"})
+		fmt.Fprint(w, `{"data":{"message":"Ad equation updated"}}`)
+	})
+
+	msg, _, err := client.Ads.UpdateEquation(1, "bitstampusd*1.02")
+	if err != nil {
+		t.Errorf("Ads.UpdateEquation returned error: %v", err)
+	}
+
+	if want := "Ad equation updated"; msg != want {
+		t.Errorf("Ads.UpdateEquation returned %q, want %q", msg, want)
+	}
+}
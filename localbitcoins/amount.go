@@ -0,0 +1,337 @@
+package localbitcoins
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"net/url"
+	"strings"
+)
+
+// satoshiScale is the number of decimal places in a bitcoin amount expressed
+// in satoshis.
+const satoshiScale = 8
+
+// Amount is an exact decimal amount of bitcoin or fiat currency, as used by the
+// LocalBitcoins API. Unlike float64, an Amount never loses precision: it is
+// encoded to and decoded from JSON as the same decimal string the API uses.
+//
+// A single type serves both currencies. Fiat amounts keep whatever precision
+// they were given. Bitcoin amounts are satoshi-backed by converting them with
+// ParseBTC, FromSatoshis and Satoshis, which keep them at exactly eight decimal
+// places and reject anything finer than a satoshi; WalletService does so
+// before sending bitcoin.
+//
+// Amounts are stored as their canonical decimal string rather than as a
+// big.Int and a scale, so that they remain comparable with == and
+// reflect.DeepEqual, usable as map keys, and cheap to copy. Arithmetic parses
+// the string again, which costs little next to the API requests amounts come
+// from.
+//
+// The zero value of Amount is zero.
+type Amount struct {
+	// Canonical decimal representation, such as "-12.3400". An empty string
+	// represents zero.
+	s string
+}
+
+// ParseAmount parses a decimal string, such as "0.00012345" or "-10", into an
+// Amount. The number of decimal places in s is preserved.
+func ParseAmount(s string) (Amount, error) {
+	v, scale, err := parseDecimal(s)
+	if err != nil {
+		return Amount{}, err
+	}
+	return newAmount(v, scale), nil
+}
+
+// MustParseAmount is like ParseAmount but panics if s cannot be parsed. It
+// simplifies the initialization of amounts from constant strings.
+func MustParseAmount(s string) Amount {
+	a, err := ParseAmount(s)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// NewAmount returns the Amount equal to v * 10^-scale. For example,
+// NewAmount(1050, 2) is 10.50.
+func NewAmount(v int64, scale int) Amount {
+	if scale < 0 {
+		return newAmount(new(big.Int).Mul(big.NewInt(v), pow10(-scale)), 0)
+	}
+	return newAmount(big.NewInt(v), scale)
+}
+
+// FromSatoshis returns the Amount of bitcoin equal to n satoshis. The result
+// has exactly eight decimal places.
+func FromSatoshis(n int64) Amount {
+	return newAmount(big.NewInt(n), satoshiScale)
+}
+
+// ParseBTC parses a decimal amount of bitcoin, such as "0.5", into an Amount
+// with exactly eight decimal places. An error is returned if s is more precise
+// than a satoshi.
+func ParseBTC(s string) (Amount, error) {
+	a, err := ParseAmount(s)
+	if err != nil {
+		return Amount{}, err
+	}
+	n, err := a.Satoshis()
+	if err != nil {
+		return Amount{}, err
+	}
+	return FromSatoshis(n), nil
+}
+
+// Satoshis returns a, which must be an amount of bitcoin, in satoshis. An error
+// is returned if a has more precision than a satoshi or does not fit in an
+// int64.
+func (a Amount) Satoshis() (int64, error) {
+	v, scale := a.decimal()
+	if scale > satoshiScale {
+		q, r := new(big.Int).QuoRem(v, pow10(scale-satoshiScale), new(big.Int))
+		if r.Sign() != 0 {
+			return 0, fmt.Errorf("localbitcoins: %v has more precision than a satoshi", a)
+		}
+		v = q
+	} else {
+		v = rescale(v, scale, satoshiScale)
+	}
+	if v.Cmp(big.NewInt(math.MaxInt64)) > 0 || v.Cmp(big.NewInt(math.MinInt64)) < 0 {
+		return 0, fmt.Errorf("localbitcoins: %v satoshis overflows int64", v)
+	}
+	return v.Int64(), nil
+}
+
+// String returns the decimal representation of a.
+func (a Amount) String() string {
+	if a.s == "" {
+		return "0"
+	}
+	return a.s
+}
+
+// IsZero reports whether a is zero.
+func (a Amount) IsZero() bool {
+	v, _ := a.decimal()
+	return v.Sign() == 0
+}
+
+// Add returns the sum a+b. The result has the larger of the scales of a and b.
+func (a Amount) Add(b Amount) Amount {
+	x, y, scale := align(a, b)
+	return newAmount(x.Add(x, y), scale)
+}
+
+// Sub returns the difference a-b. The result has the larger of the scales of a
+// and b.
+func (a Amount) Sub(b Amount) Amount {
+	x, y, scale := align(a, b)
+	return newAmount(x.Sub(x, y), scale)
+}
+
+// Mul returns the product a*b. The result has the sum of the scales of a and b;
+// use Round to reduce it.
+func (a Amount) Mul(b Amount) Amount {
+	x, xs := a.decimal()
+	y, ys := b.decimal()
+	return newAmount(x.Mul(x, y), xs+ys)
+}
+
+// Neg returns -a.
+func (a Amount) Neg() Amount {
+	v, scale := a.decimal()
+	return newAmount(v.Neg(v), scale)
+}
+
+// Abs returns the absolute value of a.
+func (a Amount) Abs() Amount {
+	v, scale := a.decimal()
+	return newAmount(v.Abs(v), scale)
+}
+
+// Cmp compares a and b and returns -1 if a < b, 0 if a == b and +1 if a > b.
+// Amounts that differ only in scale, such as 1.5 and 1.50, are equal.
+func (a Amount) Cmp(b Amount) int {
+	x, y, _ := align(a, b)
+	return x.Cmp(y)
+}
+
+// Equal reports whether a and b represent the same value, regardless of their
+// scales.
+func (a Amount) Equal(b Amount) bool {
+	return a.Cmp(b) == 0
+}
+
+// Sign returns -1 if a < 0, 0 if a == 0 and +1 if a > 0.
+func (a Amount) Sign() int {
+	v, _ := a.decimal()
+	return v.Sign()
+}
+
+// Scale returns the number of decimal places of a.
+func (a Amount) Scale() int {
+	_, scale := a.decimal()
+	return scale
+}
+
+// Round returns a rounded to the provided number of decimal places, rounding
+// halves away from zero. The result always has exactly that many decimal
+// places, so Round may also be used to pad a with zeros.
+func (a Amount) Round(places int) Amount {
+	if places < 0 {
+		places = 0
+	}
+
+	v, scale := a.decimal()
+	if scale <= places {
+		return newAmount(rescale(v, scale, places), places)
+	}
+
+	exp := pow10(scale - places)
+	q, r := new(big.Int).QuoRem(v, exp, new(big.Int))
+	// round away from zero if the remainder is at least half of exp
+	if r.Abs(r).Lsh(r, 1).Cmp(exp) >= 0 {
+		if v.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return newAmount(q, places)
+}
+
+// StringFixed returns the decimal representation of a rounded to the provided
+// number of decimal places, as Round does.
+func (a Amount) StringFixed(places int) string {
+	return a.Round(places).String()
+}
+
+// Float64 returns the float64 value nearest to a. Precision may be lost, so
+// Float64 should only be used for display or approximate calculations.
+func (a Amount) Float64() float64 {
+	r, _ := new(big.Rat).SetString(a.String())
+	f, _ := r.Float64()
+	return f
+}
+
+// MarshalJSON encodes a as a JSON string, which is how the LocalBitcoins API
+// represents amounts.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+// UnmarshalJSON decodes a from either a JSON string or a JSON number. A JSON
+// null leaves a unchanged.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		return nil
+	}
+
+	s := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+
+	v, err := ParseAmount(s)
+	if err != nil {
+		return err
+	}
+	*a = v
+	return nil
+}
+
+// EncodeValues encodes a as a URL query or form parameter. It implements the
+// query.Encoder interface.
+func (a Amount) EncodeValues(key string, v *url.Values) error {
+	v.Set(key, a.String())
+	return nil
+}
+
+// Parses a decimal string into its unscaled value and scale, such that the
+// decimal equals v * 10^-scale.
+func parseDecimal(s string) (v *big.Int, scale int, err error) {
+	digits := strings.TrimPrefix(strings.TrimPrefix(s, "-"), "+")
+	if len(s)-len(digits) > 1 {
+		return nil, 0, fmt.Errorf("localbitcoins: invalid amount %q", s)
+	}
+
+	intPart, fracPart := digits, ""
+	if i := strings.IndexByte(digits, '.'); i >= 0 {
+		intPart, fracPart = digits[:i], digits[i+1:]
+	}
+	if intPart == "" && fracPart == "" {
+		return nil, 0, fmt.Errorf("localbitcoins: invalid amount %q", s)
+	}
+	for _, c := range intPart + fracPart {
+		if c < '0' || c > '9' {
+			return nil, 0, fmt.Errorf("localbitcoins: invalid amount %q", s)
+		}
+	}
+
+	v, _ = new(big.Int).SetString("0"+intPart+fracPart, 10)
+	if strings.HasPrefix(s, "-") {
+		v.Neg(v)
+	}
+	return v, len(fracPart), nil
+}
+
+// Returns the Amount equal to v * 10^-scale.
+func newAmount(v *big.Int, scale int) Amount {
+	neg := v.Sign() < 0
+	digits := new(big.Int).Abs(v).String()
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+
+	s := digits
+	if scale > 0 {
+		s = digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+	}
+	if neg {
+		s = "-" + s
+	}
+	if s == "0" {
+		// keep the zero value and a parsed zero equal
+		s = ""
+	}
+	return Amount{s: s}
+}
+
+// Returns the unscaled value and scale of a.
+func (a Amount) decimal() (*big.Int, int) {
+	if a.s == "" {
+		return new(big.Int), 0
+	}
+	v, scale, _ := parseDecimal(a.s)
+	return v, scale
+}
+
+// Returns the unscaled values of a and b at a common scale, along with that
+// scale.
+func align(a, b Amount) (x, y *big.Int, scale int) {
+	x, xs := a.decimal()
+	y, ys := b.decimal()
+	if xs < ys {
+		return rescale(x, xs, ys), y, ys
+	}
+	return x, rescale(y, ys, xs), xs
+}
+
+// Returns the unscaled value v at scale from, converted to scale to. to must
+// not be less than from.
+func rescale(v *big.Int, from, to int) *big.Int {
+	return v.Mul(v, pow10(to-from))
+}
+
+// Returns 10^n.
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
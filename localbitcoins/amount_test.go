@@ -0,0 +1,236 @@
+package localbitcoins
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+)
+
+// Returns a pointer to the Amount parsed from s.
+func amountPtr(s string) *Amount {
+	a := MustParseAmount(s)
+	return &a
+}
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"0", "0"},
+		{"0.00", "0.00"},
+		{"-0.00", "0.00"},
+		{"10", "10"},
+		{"+10", "10"},
+		{"007.50", "7.50"},
+		{".5", "0.5"},
+		{"5.", "5"},
+		{"-0.00000001", "-0.00000001"},
+		{"123456789012345678901234567890.123456789", "123456789012345678901234567890.123456789"},
+	}
+
+	for _, tt := range tests {
+		a, err := ParseAmount(tt.in)
+		if err != nil {
+			t.Errorf("ParseAmount(%q) returned error: %v", tt.in, err)
+			continue
+		}
+		if got := a.String(); got != tt.want {
+			t.Errorf("ParseAmount(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseAmount_invalid(t *testing.T) {
+	for _, in := range []string{"", ".", "-", "1e5", "1.2.3", "--1", "+-1", "abc", "1,000"} {
+		if _, err := ParseAmount(in); err == nil {
+			t.Errorf("ParseAmount(%q) returned no error", in)
+		}
+	}
+}
+
+func TestAmount_zero(t *testing.T) {
+	zero, _ := ParseAmount("0")
+	if zero != (Amount{}) {
+		t.Errorf("ParseAmount(%q) = %#v, want the zero Amount", "0", zero)
+	}
+	if !(Amount{}).IsZero() {
+		t.Errorf("Amount{}.IsZero() = false, want true")
+	}
+}
+
+func TestAmount_satoshis(t *testing.T) {
+	a := FromSatoshis(12345)
+	if got, want := a.String(), "0.00012345"; got != want {
+		t.Errorf("FromSatoshis(12345) = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		in   string
+		want int64
+	}{
+		{"0.00012345", 12345},
+		{"1", 100000000},
+		{"-21000000", -2100000000000000},
+		{"0.1000000000", 10000000},
+	}
+	for _, tt := range tests {
+		a, _ := ParseAmount(tt.in)
+		got, err := a.Satoshis()
+		if err != nil {
+			t.Errorf("%v.Satoshis() returned error: %v", tt.in, err)
+		}
+		if got != tt.want {
+			t.Errorf("%v.Satoshis() = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{"0.000000001", "100000000000000"} {
+		a, _ := ParseAmount(in)
+		if _, err := a.Satoshis(); err == nil {
+			t.Errorf("%v.Satoshis() returned no error", in)
+		}
+	}
+}
+
+func TestParseBTC(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"0.5", "0.50000000"},
+		{"1", "1.00000000"},
+		{"0.1234567800", "0.12345678"},
+	}
+	for _, tt := range tests {
+		a, err := ParseBTC(tt.in)
+		if err != nil {
+			t.Errorf("ParseBTC(%q) returned error: %v", tt.in, err)
+		}
+		if got := a.String(); got != tt.want {
+			t.Errorf("ParseBTC(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{"0.123456789", "x"} {
+		if _, err := ParseBTC(in); err == nil {
+			t.Errorf("ParseBTC(%q) returned no error", in)
+		}
+	}
+}
+
+func TestAmount_JSON(t *testing.T) {
+	type T struct {
+		A *Amount `json:"a,omitempty"`
+	}
+
+	a, _ := ParseAmount("0.12345678901234567890")
+	testJSONMarshal(t, &T{A: &a}, `{"a":"0.12345678901234567890"}`)
+
+	v := new(T)
+	if err := json.Unmarshal([]byte(`{"a":1.50}`), v); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+	if got, want := v.A.String(), "1.50"; got != want {
+		t.Errorf("json.Unmarshal of a number = %v, want %v", got, want)
+	}
+
+	if err := json.Unmarshal([]byte(`{"a":"x"}`), v); err == nil {
+		t.Errorf("Expected error to be returned.")
+	}
+}
+
+func TestAmount_EncodeValues(t *testing.T) {
+	a, _ := ParseAmount("0.5")
+	v := url.Values{}
+	a.EncodeValues("amount", &v)
+	if got, want := v.Encode(), "amount=0.5"; got != want {
+		t.Errorf("EncodeValues = %v, want %v", got, want)
+	}
+}
+
+func TestAmount_arithmetic(t *testing.T) {
+	a, b := MustParseAmount("1.5"), MustParseAmount("0.25")
+
+	tests := []struct {
+		name string
+		got  Amount
+		want string
+	}{
+		{"Add", a.Add(b), "1.75"},
+		{"Sub", a.Sub(b), "1.25"},
+		{"Sub", b.Sub(a), "-1.25"},
+		{"Mul", a.Mul(b), "0.375"},
+		{"Neg", a.Neg(), "-1.5"},
+		{"Abs", a.Neg().Abs(), "1.5"},
+		{"Add", FromSatoshis(1).Add(MustParseAmount("0.1")), "0.10000001"},
+		{"NewAmount", NewAmount(1050, 2), "10.50"},
+		{"NewAmount", NewAmount(3, -2), "300"},
+	}
+	for _, tt := range tests {
+		if got := tt.got.String(); got != tt.want {
+			t.Errorf("%v = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAmount_Cmp(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.5", "1.50", 0},
+		{"1.5", "1.49", 1},
+		{"-1", "0", -1},
+		{"0.00000001", "0.00000002", -1},
+	}
+	for _, tt := range tests {
+		a, b := MustParseAmount(tt.a), MustParseAmount(tt.b)
+		if got := a.Cmp(b); got != tt.want {
+			t.Errorf("%v.Cmp(%v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+		if got := a.Equal(b); got != (tt.want == 0) {
+			t.Errorf("%v.Equal(%v) = %v, want %v", tt.a, tt.b, got, tt.want == 0)
+		}
+	}
+
+	if got := MustParseAmount("-0.5").Sign(); got != -1 {
+		t.Errorf("Sign() = %v, want -1", got)
+	}
+	if got := MustParseAmount("1.230").Scale(); got != 3 {
+		t.Errorf("Scale() = %v, want 3", got)
+	}
+}
+
+func TestAmount_Round(t *testing.T) {
+	tests := []struct {
+		in     string
+		places int
+		want   string
+	}{
+		{"1.005", 2, "1.01"},
+		{"1.004", 2, "1.00"},
+		{"-1.005", 2, "-1.01"},
+		{"1.5", 0, "2"},
+		{"1.5", 4, "1.5000"},
+		{"0.123456789", 8, "0.12345679"},
+	}
+	for _, tt := range tests {
+		if got := MustParseAmount(tt.in).StringFixed(tt.places); got != tt.want {
+			t.Errorf("%v.StringFixed(%v) = %v, want %v", tt.in, tt.places, got, tt.want)
+		}
+	}
+}
+
+func TestAmount_Float64(t *testing.T) {
+	if got := MustParseAmount("0.25").Float64(); got != 0.25 {
+		t.Errorf("Float64() = %v, want 0.25", got)
+	}
+}
+
+func TestMustParseAmount_panics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MustParseAmount did not panic")
+		}
+	}()
+	MustParseAmount("x")
+}
@@ -0,0 +1,408 @@
+package localbitcoins
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ContactsService handles all communications related to contacts (trades)
+// with the LocalBitcoins API.
+//
+// Methods that perform an action on a contact, such as Release, fetch the
+// contact once the action succeeded and return it. If that fetch fails, they
+// return a *ContactRefreshError, as the action has taken effect regardless.
+type ContactsService struct {
+	client *Client
+}
+
+// Contact represents a contact, which is what the LocalBitcoins API calls a
+// trade.
+type Contact struct {
+	ID                    *int                   `json:"contact_id,omitempty"`
+	CreatedAt             *time.Time             `json:"created_at,omitempty"`
+	ReferenceCode         *string                `json:"reference_code,omitempty"`
+	Buyer                 *Profile               `json:"buyer,omitempty"`
+	Seller                *Profile               `json:"seller,omitempty"`
+	Advertisement         *ContactAd             `json:"advertisement,omitempty"`
+	Currency              *string                `json:"currency,omitempty"`
+	Amount                *Amount                `json:"amount,omitempty"`
+	AmountBTC             *Amount                `json:"amount_btc,omitempty"`
+	FeeBTC                *Amount                `json:"fee_btc,omitempty"`
+	ExchangeRateUpdatedAt *time.Time             `json:"exchange_rate_updated_at,omitempty"`
+	IsBuying              *bool                  `json:"is_buying,omitempty"`
+	IsSelling             *bool                  `json:"is_selling,omitempty"`
+	IsFunded              *bool                  `json:"is_funded,omitempty"`
+	PaymentCompletedAt    *time.Time             `json:"payment_completed_at,omitempty"`
+	FundedAt              *time.Time             `json:"funded_at,omitempty"`
+	EscrowedAt            *time.Time             `json:"escrowed_at,omitempty"`
+	ReleasedAt            *time.Time             `json:"released_at,omitempty"`
+	DisputedAt            *time.Time             `json:"disputed_at,omitempty"`
+	CanceledAt            *time.Time             `json:"canceled_at,omitempty"`
+	ClosedAt              *time.Time             `json:"closed_at,omitempty"`
+	AccountDetails        map[string]interface{} `json:"account_details,omitempty"`
+
+	// Actions holds the URLs of actions available for the contact. It is
+	// populated from the actions object that accompanies the contact.
+	Actions *ContactActions `json:"-"`
+}
+
+func (c Contact) String() string {
+	return Stringify(c)
+}
+
+// ContactAd is the summary of the advertisement a contact was opened from.
+type ContactAd struct {
+	ID            *int       `json:"id,omitempty"`
+	PaymentMethod *string    `json:"payment_method,omitempty"`
+	TradeType     *TradeType `json:"trade_type,omitempty"`
+	Advertiser    *Profile   `json:"advertiser,omitempty"`
+}
+
+func (a ContactAd) String() string {
+	return Stringify(a)
+}
+
+// ContactActions holds the URLs of actions available for a contact.
+type ContactActions struct {
+	ReleaseURL                *string `json:"release_url,omitempty"`
+	AdvertisementURL          *string `json:"advertisement_url,omitempty"`
+	AdvertisementPublicView   *string `json:"advertisement_public_view,omitempty"`
+	MessagesURL               *string `json:"messages_url,omitempty"`
+	MessagePostURL            *string `json:"message_post_url,omitempty"`
+	MarkAsPaidURL             *string `json:"mark_as_paid_url,omitempty"`
+	DisputeURL                *string `json:"dispute_url,omitempty"`
+	CancelURL                 *string `json:"cancel_url,omitempty"`
+	FundURL                   *string `json:"fund_url,omitempty"`
+	ContactURL                *string `json:"contact_url,omitempty"`
+	MarkIdentifiedURL         *string `json:"mark_identified_url,omitempty"`
+	MarkRealNameConfirmedURL  *string `json:"mark_realname_confirmed_url,omitempty"`
+	RealNameVerificationsURL  *string `json:"realname_verifications_url,omitempty"`
+	IdentityVerificationsURL  *string `json:"identity_verifications_url,omitempty"`
+	MarkIdentityConfirmedURL  *string `json:"mark_identity_confirmed_url,omitempty"`
+	AdvertisementCancelledURL *string `json:"advertisement_cancelled_url,omitempty"`
+}
+
+func (a ContactActions) String() string {
+	return Stringify(a)
+}
+
+// ContactCreateOptions specifies the parameters to the ContactsService.Create
+// method.
+type ContactCreateOptions struct {
+	// Amount, in the currency of the advertisement, to trade.
+	Amount Amount `url:"amount"`
+
+	// Message to send to the advertiser when opening the contact.
+	Message string `url:"message,omitempty"`
+}
+
+// RealNameStatus is a confirmation status for the real name of a contact's
+// counterparty, as used by ContactsService.MarkRealName.
+type RealNameStatus int
+
+// Real name confirmation statuses supported by the LocalBitcoins API.
+const (
+	RealNameMatches RealNameStatus = iota + 1
+	RealNameDifferent
+	RealNameNotChecked
+	RealNameNotVisible
+)
+
+// RealNameOptions specifies the parameters to the ContactsService.MarkRealName
+// method.
+type RealNameOptions struct {
+	ConfirmationStatus RealNameStatus `url:"confirmation_status"`
+
+	// Whether the counterparty's identity was verified as well.
+	IDConfirmed bool `url:"id_confirmed,omitempty"`
+}
+
+// Contact list middleman used strictly for unmarshaling the API response.
+type contactListMiddleman struct {
+	Contacts []*contactMiddleman `json:"contact_list,omitempty"`
+}
+
+// Middleman used strictly for unmarshaling individual contacts.
+type contactMiddleman struct {
+	Contact *Contact        `json:"data,omitempty"`
+	Actions *ContactActions `json:"actions,omitempty"`
+}
+
+// Returns the contacts held by the middleman, with their actions attached.
+func (m *contactListMiddleman) contacts() []*Contact {
+	contacts := make([]*Contact, len(m.Contacts))
+	for i, c := range m.Contacts {
+		contacts[i] = c.Contact
+		if contacts[i] == nil {
+			contacts[i] = new(Contact)
+		}
+		contacts[i].Actions = c.Actions
+	}
+	return contacts
+}
+
+// Middleman used strictly for unmarshaling the result of creating a contact.
+type contactCreateMiddleman struct {
+	Message *string `json:"message,omitempty"`
+	ID      *int    `json:"contact_id,omitempty"`
+	Funded  *bool   `json:"funded,omitempty"`
+}
+
+// Middleman used strictly for unmarshaling the message returned by contact
+// actions.
+type contactResultMiddleman struct {
+	Message *string `json:"message,omitempty"`
+}
+
+// ContactRefreshError is returned when an action on a contact succeeded but
+// the contact could not be fetched afterwards. The action has taken effect
+// regardless, so it must not be repeated.
+type ContactRefreshError struct {
+	ID      int    // ID of the contact
+	Message string // message returned by the action
+	Err     error  // error of fetching the contact
+}
+
+func (e *ContactRefreshError) Error() string {
+	return fmt.Sprintf("localbitcoins: contact %v updated (%v) but not refreshed: %v",
+		e.ID, e.Message, e.Err)
+}
+
+// Unwrap returns the error of fetching the contact.
+func (e *ContactRefreshError) Unwrap() error {
+	return e.Err
+}
+
+// Get fetches a contact by its ID.
+func (s *ContactsService) Get(id int) (*Contact, *Response, error) {
+	return s.GetContext(context.Background(), id)
+}
+
+// GetContext is like Get but uses ctx to control its requests.
+func (s *ContactsService) GetContext(ctx context.Context, id int) (*Contact, *Response, error) {
+	u := fmt.Sprintf("api/contact_info/%v/", id)
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	contact := new(Contact)
+	actions := new(ContactActions)
+	respMiddleman := &ResponseData{Data: contact, Actions: actions}
+	resp, err := s.client.DoContext(ctx, req, respMiddleman)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	contact.Actions = actions
+	return contact, resp, err
+}
+
+// GetMany fetches the contacts with the provided IDs in a single request.
+func (s *ContactsService) GetMany(ids ...int) ([]*Contact, *Response, error) {
+	return s.GetManyContext(context.Background(), ids...)
+}
+
+// GetManyContext is like GetMany but uses ctx to control its requests.
+func (s *ContactsService) GetManyContext(ctx context.Context, ids ...int) ([]*Contact, *Response, error) {
+	opt := struct {
+		Contacts []int `url:"contacts,comma"`
+	}{ids}
+	u, err := addOptions("api/contact_info/", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	middleman := new(contactListMiddleman)
+	respMiddleman := &ResponseData{Data: middleman}
+	resp, err := s.client.DoContext(ctx, req, respMiddleman)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return middleman.contacts(), resp, err
+}
+
+// Create opens a new contact from the advertisement with the provided ID and
+// returns it as fetched after it was opened. If the contact was opened but
+// could not be fetched, a *ContactRefreshError is returned along with a
+// contact that only has its ID, funding status and actions populated.
+func (s *ContactsService) Create(adID int, opt *ContactCreateOptions) (*Contact, *Response, error) {
+	return s.CreateContext(context.Background(), adID, opt)
+}
+
+// CreateContext is like Create but uses ctx to control its requests.
+func (s *ContactsService) CreateContext(ctx context.Context, adID int, opt *ContactCreateOptions) (*Contact, *Response, error) {
+	u := fmt.Sprintf("api/contact_create/%v/", adID)
+	req, err := s.client.NewFormRequest("POST", u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	middleman := new(contactCreateMiddleman)
+	actions := new(ContactActions)
+	respMiddleman := &ResponseData{Data: middleman, Actions: actions}
+	resp, err := s.client.DoContext(ctx, req, respMiddleman)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	contact := &Contact{
+		ID:       middleman.ID,
+		IsFunded: middleman.Funded,
+		Actions:  actions,
+	}
+	if contact.ID == nil {
+		return contact, resp, err
+	}
+
+	var msg string
+	if middleman.Message != nil {
+		msg = *middleman.Message
+	}
+	refreshed, err := s.refresh(ctx, *contact.ID, msg)
+	if err != nil {
+		return contact, resp, err
+	}
+	return refreshed, resp, err
+}
+
+// MarkAsPaid marks the contact with the provided ID as paid. The updated
+// contact is returned.
+func (s *ContactsService) MarkAsPaid(id int) (*Contact, *Response, error) {
+	return s.MarkAsPaidContext(context.Background(), id)
+}
+
+// MarkAsPaidContext is like MarkAsPaid but uses ctx to control its requests.
+func (s *ContactsService) MarkAsPaidContext(ctx context.Context, id int) (*Contact, *Response, error) {
+	return s.action(ctx, "contact_mark_as_paid", id, nil)
+}
+
+// Release releases the escrow of the contact with the provided ID. The updated
+// contact is returned.
+func (s *ContactsService) Release(id int) (*Contact, *Response, error) {
+	return s.ReleaseContext(context.Background(), id)
+}
+
+// ReleaseContext is like Release but uses ctx to control its requests.
+func (s *ContactsService) ReleaseContext(ctx context.Context, id int) (*Contact, *Response, error) {
+	return s.action(ctx, "contact_release", id, nil)
+}
+
+// ReleaseWithPIN releases the escrow of the contact with the provided ID,
+// authorizing the release with the account's PIN code. The updated contact
+// is returned.
+func (s *ContactsService) ReleaseWithPIN(id int, pin string) (*Contact, *Response, error) {
+	return s.ReleaseWithPINContext(context.Background(), id, pin)
+}
+
+// ReleaseWithPINContext is like ReleaseWithPIN but uses ctx to control its
+// requests.
+func (s *ContactsService) ReleaseWithPINContext(ctx context.Context, id int, pin string) (*Contact, *Response, error) {
+	opt := struct {
+		PIN string `url:"pincode"`
+	}{pin}
+	return s.action(ctx, "contact_release_pin", id, opt)
+}
+
+// Cancel cancels the contact with the provided ID. The updated contact is
+// returned.
+func (s *ContactsService) Cancel(id int) (*Contact, *Response, error) {
+	return s.CancelContext(context.Background(), id)
+}
+
+// CancelContext is like Cancel but uses ctx to control its requests.
+func (s *ContactsService) CancelContext(ctx context.Context, id int) (*Contact, *Response, error) {
+	return s.action(ctx, "contact_cancel", id, nil)
+}
+
+// Dispute starts a dispute for the contact with the provided ID. An optional
+// topic describing the dispute may be provided. The updated contact is
+// returned.
+func (s *ContactsService) Dispute(id int, topic string) (*Contact, *Response, error) {
+	return s.DisputeContext(context.Background(), id, topic)
+}
+
+// DisputeContext is like Dispute but uses ctx to control its requests.
+func (s *ContactsService) DisputeContext(ctx context.Context, id int, topic string) (*Contact, *Response, error) {
+	opt := struct {
+		Topic string `url:"topic,omitempty"`
+	}{topic}
+	return s.action(ctx, "contact_dispute", id, opt)
+}
+
+// Fund funds the unfunded contact with the provided ID from the wallet of the
+// authenticated account. The updated contact is returned.
+func (s *ContactsService) Fund(id int) (*Contact, *Response, error) {
+	return s.FundContext(context.Background(), id)
+}
+
+// FundContext is like Fund but uses ctx to control its requests.
+func (s *ContactsService) FundContext(ctx context.Context, id int) (*Contact, *Response, error) {
+	return s.action(ctx, "contact_fund", id, nil)
+}
+
+// MarkIdentified marks the counterparty of the contact with the provided ID as
+// identified. The updated contact is returned.
+func (s *ContactsService) MarkIdentified(id int) (*Contact, *Response, error) {
+	return s.MarkIdentifiedContext(context.Background(), id)
+}
+
+// MarkIdentifiedContext is like MarkIdentified but uses ctx to control its
+// requests.
+func (s *ContactsService) MarkIdentifiedContext(ctx context.Context, id int) (*Contact, *Response, error) {
+	return s.action(ctx, "contact_mark_identified", id, nil)
+}
+
+// MarkRealName marks the real name of the counterparty of the contact with the
+// provided ID as confirmed or not. The updated contact is returned.
+func (s *ContactsService) MarkRealName(id int, opt *RealNameOptions) (*Contact, *Response, error) {
+	return s.MarkRealNameContext(context.Background(), id, opt)
+}
+
+// MarkRealNameContext is like MarkRealName but uses ctx to control its
+// requests.
+func (s *ContactsService) MarkRealNameContext(ctx context.Context, id int, opt *RealNameOptions) (*Contact, *Response, error) {
+	return s.action(ctx, "contact_mark_realname", id, opt)
+}
+
+// Performs the named action on the contact with the provided ID, posting the
+// parameters in opt, and returns the contact as fetched afterwards. If the
+// contact cannot be fetched, a *ContactRefreshError is returned.
+func (s *ContactsService) action(ctx context.Context, name string, id int, opt interface{}) (*Contact, *Response, error) {
+	u := fmt.Sprintf("api/%v/%v/", name, id)
+	req, err := s.client.NewFormRequest("POST", u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := new(contactResultMiddleman)
+	respMiddleman := &ResponseData{Data: result}
+	resp, err := s.client.DoContext(ctx, req, respMiddleman)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var msg string
+	if result.Message != nil {
+		msg = *result.Message
+	}
+	contact, err := s.refresh(ctx, id, msg)
+	return contact, resp, err
+}
+
+// Fetches the contact with the provided ID after an action that returned msg
+// succeeded on it.
+func (s *ContactsService) refresh(ctx context.Context, id int, msg string) (*Contact, error) {
+	contact, _, err := s.GetContext(ctx, id)
+	if err != nil {
+		return nil, &ContactRefreshError{ID: id, Message: msg, Err: err}
+	}
+	return contact, nil
+}
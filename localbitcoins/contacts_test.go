@@ -0,0 +1,245 @@
+package localbitcoins
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestContact_marshall(t *testing.T) {
+	testJSONMarshal(t, &Contact{}, "{}")
+
+	tt := OnlineSell
+	c := &Contact{
+		ID:            Int(1),
+		ReferenceCode: String("L1"),
+		Buyer:         &Profile{Username: String("foo")},
+		Advertisement: &ContactAd{ID: Int(2), TradeType: &tt},
+		Currency:      String("EUR"),
+		Amount:        amountPtr("100"),
+		AmountBTC:     amountPtr("0.5"),
+		IsFunded:      Bool(true),
+	}
+	want := `{
+    "contact_id": 1,
+    "reference_code": "L1",
+    "buyer": {"username": "foo"},
+    "advertisement": {"id": 2, "trade_type": "ONLINE_SELL"},
+    "currency": "EUR",
+    "amount": "100",
+    "amount_btc": "0.5",
+    "is_funded": true
+  }`
+	testJSONMarshal(t, c, want)
+}
+
+func TestContactsService_Get(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/contact_info/1/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{
+      "data":{"contact_id":1},
+      "actions":{"release_url":"/api/contact_release/1/"}
+    }`)
+	})
+
+	contact, _, err := client.Contacts.Get(1)
+	if err != nil {
+		t.Errorf("Contacts.Get returned error: %v", err)
+	}
+
+	want := &Contact{
+		ID:      Int(1),
+		Actions: &ContactActions{ReleaseURL: String("/api/contact_release/1/")},
+	}
+	if !reflect.DeepEqual(contact, want) {
+		t.Errorf("Contacts.Get returned %+v, want %+v", contact, want)
+	}
+}
+
+func TestContactsService_GetMany(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/contact_info/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"contacts": "1,2"})
+		fmt.Fprint(w, `{
+      "data":{
+        "contact_list":[
+          {"data":{"contact_id":1},"actions":{"cancel_url":"foo"}},
+          {"data":{"contact_id":2}}
+        ],
+        "contact_count":2
+      }
+    }`)
+	})
+
+	contacts, _, err := client.Contacts.GetMany(1, 2)
+	if err != nil {
+		t.Errorf("Contacts.GetMany returned error: %v", err)
+	}
+
+	want := []*Contact{
+		&Contact{ID: Int(1), Actions: &ContactActions{CancelURL: String("foo")}},
+		&Contact{ID: Int(2)},
+	}
+	if !reflect.DeepEqual(contacts, want) {
+		t.Errorf("Contacts.GetMany returned %+v, want %+v", contacts, want)
+	}
+}
+
+func TestContactsService_Create(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/contact_create/7/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testFormValues(t, r, values{"amount": "150.5", "message": "hi"})
+		fmt.Fprint(w, `{
+      "data":{"message":"OK","contact_id":3,"funded":false},
+      "actions":{"contact_url":"bar"}
+    }`)
+	})
+
+	mux.HandleFunc("/api/contact_info/3/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{
+      "data":{"contact_id":3,"reference_code":"L3","is_funded":false},
+      "actions":{"contact_url":"bar"}
+    }`)
+	})
+
+	opt := &ContactCreateOptions{Amount: MustParseAmount("150.5"), Message: "hi"}
+	contact, _, err := client.Contacts.Create(7, opt)
+	if err != nil {
+		t.Errorf("Contacts.Create returned error: %v", err)
+	}
+
+	want := &Contact{
+		ID:            Int(3),
+		ReferenceCode: String("L3"),
+		IsFunded:      Bool(false),
+		Actions:       &ContactActions{ContactURL: String("bar")},
+	}
+	if !reflect.DeepEqual(contact, want) {
+		t.Errorf("Contacts.Create returned %+v, want %+v", contact, want)
+	}
+}
+
+func TestContactsService_Create_refreshError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/contact_create/7/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"message":"OK","contact_id":3,"funded":true}}`)
+	})
+	mux.HandleFunc("/api/contact_info/3/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	opt := &ContactCreateOptions{Amount: MustParseAmount("1")}
+	contact, _, err := client.Contacts.Create(7, opt)
+	if err, ok := err.(*ContactRefreshError); !ok || err.ID != 3 || err.Message != "OK" {
+		t.Errorf("Expected a ContactRefreshError for contact 3; got %#v.", err)
+	}
+	if contact == nil || contact.ID == nil || *contact.ID != 3 {
+		t.Errorf("Contacts.Create returned %+v, want the created contact", contact)
+	}
+}
+
+func TestContactsService_actions(t *testing.T) {
+	tests := []struct {
+		path   string
+		params values
+		call   func() (*Contact, *Response, error)
+	}{
+		{"/api/contact_mark_as_paid/1/", values{}, func() (*Contact, *Response, error) {
+			return client.Contacts.MarkAsPaid(1)
+		}},
+		{"/api/contact_release/1/", values{}, func() (*Contact, *Response, error) {
+			return client.Contacts.Release(1)
+		}},
+		{"/api/contact_release_pin/1/", values{"pincode": "1234"}, func() (*Contact, *Response, error) {
+			return client.Contacts.ReleaseWithPIN(1, "1234")
+		}},
+		{"/api/contact_cancel/1/", values{}, func() (*Contact, *Response, error) {
+			return client.Contacts.Cancel(1)
+		}},
+		{"/api/contact_dispute/1/", values{"topic": "late"}, func() (*Contact, *Response, error) {
+			return client.Contacts.Dispute(1, "late")
+		}},
+		{"/api/contact_fund/1/", values{}, func() (*Contact, *Response, error) {
+			return client.Contacts.Fund(1)
+		}},
+		{"/api/contact_mark_identified/1/", values{}, func() (*Contact, *Response, error) {
+			return client.Contacts.MarkIdentified(1)
+		}},
+		{"/api/contact_mark_realname/1/", values{"confirmation_status": "1", "id_confirmed": "true"}, func() (*Contact, *Response, error) {
+			opt := &RealNameOptions{ConfirmationStatus: RealNameMatches, IDConfirmed: true}
+			return client.Contacts.MarkRealName(1, opt)
+		}},
+	}
+
+	for _, tt := range tests {
+		setup()
+
+		params := tt.params
+		mux.HandleFunc(tt.path, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "POST")
+			testFormValues(t, r, params)
+			fmt.Fprint(w, `{"data":{"message":"done"}}`)
+		})
+		mux.HandleFunc("/api/contact_info/1/", func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			fmt.Fprint(w, `{"data":{"contact_id":1,"reference_code":"L1"}}`)
+		})
+
+		contact, _, err := tt.call()
+		if err != nil {
+			t.Errorf("%v returned error: %v", tt.path, err)
+		}
+		want := &Contact{
+			ID:            Int(1),
+			ReferenceCode: String("L1"),
+			Actions:       &ContactActions{},
+		}
+		if !reflect.DeepEqual(contact, want) {
+			t.Errorf("%v returned %+v, want %+v", tt.path, contact, want)
+		}
+
+		teardown()
+	}
+}
+
+func TestContactsService_action_refreshError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/contact_release/1/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"message":"released"}}`)
+	})
+	mux.HandleFunc("/api/contact_info/1/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error":{"message":"Not found","error_code":12}}`)
+	})
+
+	contact, _, err := client.Contacts.Release(1)
+	refreshErr, ok := err.(*ContactRefreshError)
+	if !ok {
+		t.Fatalf("Expected a ContactRefreshError; got %#v.", err)
+	}
+	if refreshErr.ID != 1 || refreshErr.Message != "released" {
+		t.Errorf("ContactRefreshError = %+v, want ID 1 and message released", refreshErr)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("errors.Is(%v, ErrNotFound) = false, want true", err)
+	}
+	if contact != nil {
+		t.Errorf("Contacts.Release returned %+v, want nil", contact)
+	}
+}
@@ -0,0 +1,90 @@
+package localbitcoins
+
+import "context"
+
+// DashboardService handles all dashboard-related communications with the
+// LocalBitcoins API. The dashboard lists the contacts of the authenticated
+// account, along with the URLs of the actions available for each of them.
+type DashboardService struct {
+	client *Client
+}
+
+// Open lists the open contacts of the authenticated account.
+func (s *DashboardService) Open() ([]*Contact, *Response, error) {
+	return s.OpenContext(context.Background())
+}
+
+// OpenContext is like Open but uses ctx to control its requests.
+func (s *DashboardService) OpenContext(ctx context.Context) ([]*Contact, *Response, error) {
+	return s.list(ctx, "api/dashboard/")
+}
+
+// Released lists the released contacts of the authenticated account.
+func (s *DashboardService) Released() ([]*Contact, *Response, error) {
+	return s.ReleasedContext(context.Background())
+}
+
+// ReleasedContext is like Released but uses ctx to control its requests.
+func (s *DashboardService) ReleasedContext(ctx context.Context) ([]*Contact, *Response, error) {
+	return s.list(ctx, "api/dashboard/released/")
+}
+
+// Canceled lists the canceled contacts of the authenticated account.
+func (s *DashboardService) Canceled() ([]*Contact, *Response, error) {
+	return s.CanceledContext(context.Background())
+}
+
+// CanceledContext is like Canceled but uses ctx to control its requests.
+func (s *DashboardService) CanceledContext(ctx context.Context) ([]*Contact, *Response, error) {
+	return s.list(ctx, "api/dashboard/canceled/")
+}
+
+// Closed lists the closed contacts of the authenticated account.
+func (s *DashboardService) Closed() ([]*Contact, *Response, error) {
+	return s.ClosedContext(context.Background())
+}
+
+// ClosedContext is like Closed but uses ctx to control its requests.
+func (s *DashboardService) ClosedContext(ctx context.Context) ([]*Contact, *Response, error) {
+	return s.list(ctx, "api/dashboard/closed/")
+}
+
+// OpenIterator returns an Iterator over the open contacts of the authenticated
+// account, fetching every page of the list.
+func (s *DashboardService) OpenIterator() *Iterator[*Contact] {
+	return NewIterator("api/dashboard/", s.list)
+}
+
+// ReleasedIterator returns an Iterator over the released contacts of the
+// authenticated account, fetching every page of the list.
+func (s *DashboardService) ReleasedIterator() *Iterator[*Contact] {
+	return NewIterator("api/dashboard/released/", s.list)
+}
+
+// CanceledIterator returns an Iterator over the canceled contacts of the
+// authenticated account, fetching every page of the list.
+func (s *DashboardService) CanceledIterator() *Iterator[*Contact] {
+	return NewIterator("api/dashboard/canceled/", s.list)
+}
+
+// ClosedIterator returns an Iterator over the closed contacts of the
+// authenticated account, fetching every page of the list.
+func (s *DashboardService) ClosedIterator() *Iterator[*Contact] {
+	return NewIterator("api/dashboard/closed/", s.list)
+}
+
+func (s *DashboardService) list(ctx context.Context, u string) ([]*Contact, *Response, error) {
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	middleman := new(contactListMiddleman)
+	respMiddleman := &ResponseData{Data: middleman}
+	resp, err := s.client.DoContext(ctx, req, respMiddleman)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return middleman.contacts(), resp, err
+}
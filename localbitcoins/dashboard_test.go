@@ -0,0 +1,71 @@
+package localbitcoins
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestDashboardService(t *testing.T) {
+	tests := []struct {
+		path string
+		list func() ([]*Contact, *Response, error)
+	}{
+		{"/api/dashboard/", func() ([]*Contact, *Response, error) {
+			return client.Dashboard.Open()
+		}},
+		{"/api/dashboard/released/", func() ([]*Contact, *Response, error) {
+			return client.Dashboard.Released()
+		}},
+		{"/api/dashboard/canceled/", func() ([]*Contact, *Response, error) {
+			return client.Dashboard.Canceled()
+		}},
+		{"/api/dashboard/closed/", func() ([]*Contact, *Response, error) {
+			return client.Dashboard.Closed()
+		}},
+	}
+
+	for _, tt := range tests {
+		setup()
+
+		mux.HandleFunc(tt.path, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			fmt.Fprint(w, `{
+        "data":{
+          "contact_list":[
+          {
+            "data":{"contact_id":1,"currency":"EUR"},
+            "actions":{
+              "release_url":"https://localbitcoins.com/api/contact_release/1/",
+              "messages_url":"https://localbitcoins.com/api/contact_messages/1/"
+            }
+          }
+          ],
+          "contact_count":1
+        }
+      }`)
+		})
+
+		contacts, _, err := tt.list()
+		if err != nil {
+			t.Errorf("%v returned error: %v", tt.path, err)
+		}
+
+		want := []*Contact{
+			&Contact{
+				ID:       Int(1),
+				Currency: String("EUR"),
+				Actions: &ContactActions{
+					ReleaseURL:  String("https://localbitcoins.com/api/contact_release/1/"),
+					MessagesURL: String("https://localbitcoins.com/api/contact_messages/1/"),
+				},
+			},
+		}
+		if !reflect.DeepEqual(contacts, want) {
+			t.Errorf("%v returned %+v, want %+v", tt.path, contacts, want)
+		}
+
+		teardown()
+	}
+}
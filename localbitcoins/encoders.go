@@ -0,0 +1,124 @@
+package localbitcoins
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/google/go-querystring/query"
+)
+
+// A BodyEncoder encodes the body of an API request, as used by
+// Client.NewEncodedRequest.
+type BodyEncoder interface {
+	// Encode encodes body and returns the encoded body along with its media
+	// type. The media type may be empty if there is no body to describe.
+	Encode(body interface{}) (r io.Reader, contentType string, err error)
+}
+
+// Body encoders supported by Client.NewEncodedRequest. MultipartEncoder
+// returns the encoder for multipart bodies.
+var (
+	// JSONEncoder encodes bodies as JSON. A nil body is sent as an empty body
+	// without a media type.
+	JSONEncoder BodyEncoder = jsonEncoder{}
+
+	// FormEncoder encodes bodies as application/x-www-form-urlencoded data,
+	// which is what the write endpoints of the LocalBitcoins API expect.
+	// Bodies must be structs whose fields may contain "url" tags.
+	FormEncoder BodyEncoder = formEncoder{}
+)
+
+type jsonEncoder struct{}
+
+func (jsonEncoder) Encode(body interface{}) (io.Reader, string, error) {
+	buf := new(bytes.Buffer)
+	if body == nil {
+		return buf, "", nil
+	}
+	if err := json.NewEncoder(buf).Encode(body); err != nil {
+		return nil, "", err
+	}
+	return buf, "application/json", nil
+}
+
+type formEncoder struct{}
+
+func (formEncoder) Encode(body interface{}) (io.Reader, string, error) {
+	qs, err := formValues(body)
+	if err != nil {
+		return nil, "", err
+	}
+	return strings.NewReader(qs.Encode()), "application/x-www-form-urlencoded", nil
+}
+
+// An Upload is a file included in the body of a multipart API request.
+type Upload struct {
+	Field  string    // name of the form field holding the file
+	Name   string    // file name sent to the API
+	Reader io.Reader // file contents
+}
+
+// MultipartEncoder returns a BodyEncoder that encodes bodies as
+// multipart/form-data, as used by endpoints that accept files. Bodies must be
+// structs whose fields may contain "url" tags; their fields are written as
+// form fields, in the order of their names, followed by the provided uploads.
+func MultipartEncoder(uploads ...*Upload) BodyEncoder {
+	return multipartEncoder{uploads: uploads}
+}
+
+type multipartEncoder struct {
+	uploads []*Upload
+}
+
+func (e multipartEncoder) Encode(body interface{}) (io.Reader, string, error) {
+	qs, err := formValues(body)
+	if err != nil {
+		return nil, "", err
+	}
+
+	buf := new(bytes.Buffer)
+	mw := multipart.NewWriter(buf)
+
+	keys := make([]string, 0, len(qs))
+	for k := range qs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		for _, v := range qs[k] {
+			if err := mw.WriteField(k, v); err != nil {
+				return nil, "", err
+			}
+		}
+	}
+	for _, up := range e.uploads {
+		w, err := mw.CreateFormFile(up.Field, up.Name)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err := io.Copy(w, up.Reader); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, "", err
+	}
+
+	return buf, mw.FormDataContentType(), nil
+}
+
+// Returns the fields of body, which must be nil or a struct whose fields may
+// contain "url" tags, as form values.
+func formValues(body interface{}) (url.Values, error) {
+	if v := reflect.ValueOf(body); body == nil ||
+		(v.Kind() == reflect.Ptr && v.IsNil()) {
+		return url.Values{}, nil
+	}
+	return query.Values(body)
+}
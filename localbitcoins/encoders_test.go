@@ -0,0 +1,179 @@
+package localbitcoins
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestJSONEncoder(t *testing.T) {
+	r, contentType, err := JSONEncoder.Encode(map[string]string{"a": "b"})
+	if err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+
+	body, _ := ioutil.ReadAll(r)
+	if want := `{"a":"b"}` + "\n"; string(body) != want {
+		t.Errorf("Encode body = %q, want %q", body, want)
+	}
+	if want := "application/json"; contentType != want {
+		t.Errorf("Encode content type = %v, want %v", contentType, want)
+	}
+}
+
+func TestJSONEncoder_nil(t *testing.T) {
+	r, contentType, err := JSONEncoder.Encode(nil)
+	if err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+
+	if body, _ := ioutil.ReadAll(r); len(body) != 0 {
+		t.Errorf("Encode body = %q, want empty", body)
+	}
+	if contentType != "" {
+		t.Errorf("Encode content type = %v, want none", contentType)
+	}
+}
+
+func TestFormEncoder(t *testing.T) {
+	type TestType struct {
+		Amount  Amount   `url:"amount"`
+		Message string   `url:"msg,omitempty"`
+		Tags    []string `url:"tag"`
+	}
+
+	body := &TestType{
+		Amount:  MustParseAmount("0.10"),
+		Message: "a&b=c d",
+		Tags:    []string{"x", "y"},
+	}
+	r, contentType, err := FormEncoder.Encode(body)
+	if err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+
+	b, _ := ioutil.ReadAll(r)
+	if want := "amount=0.10&msg=a%26b%3Dc+d&tag=x&tag=y"; string(b) != want {
+		t.Errorf("Encode body = %q, want %q", b, want)
+	}
+	if want := "application/x-www-form-urlencoded"; contentType != want {
+		t.Errorf("Encode content type = %v, want %v", contentType, want)
+	}
+}
+
+func TestFormEncoder_nil(t *testing.T) {
+	type TestType struct {
+		A string `url:"a"`
+	}
+
+	for _, body := range []interface{}{nil, (*TestType)(nil)} {
+		r, _, err := FormEncoder.Encode(body)
+		if err != nil {
+			t.Fatalf("Encode(%#v) returned error: %v", body, err)
+		}
+		if b, _ := ioutil.ReadAll(r); len(b) != 0 {
+			t.Errorf("Encode(%#v) body = %q, want empty", body, b)
+		}
+	}
+}
+
+func TestFormEncoder_invalid(t *testing.T) {
+	if _, _, err := FormEncoder.Encode("not a struct"); err == nil {
+		t.Errorf("Expected error to be returned")
+	}
+}
+
+func TestMultipartEncoder(t *testing.T) {
+	type TestType struct {
+		B string `url:"b"`
+		A string `url:"a"`
+	}
+
+	enc := MultipartEncoder(
+		&Upload{Field: "document", Name: "receipt.txt", Reader: strings.NewReader("paid")},
+	)
+	r, contentType, err := enc.Encode(&TestType{A: "1", B: "2"})
+	if err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != "multipart/form-data" {
+		t.Fatalf("Encode content type = %v, want multipart/form-data", contentType)
+	}
+
+	type part struct {
+		Disposition, Type, Body string
+	}
+	var parts []part
+	mr := multipart.NewReader(r, params["boundary"])
+	for {
+		p, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("NextPart returned error: %v", err)
+		}
+		b, _ := ioutil.ReadAll(p)
+		parts = append(parts, part{
+			p.Header.Get("Content-Disposition"),
+			p.Header.Get("Content-Type"),
+			string(b),
+		})
+	}
+
+	want := []part{
+		{`form-data; name="a"`, "", "1"},
+		{`form-data; name="b"`, "", "2"},
+		{`form-data; name="document"; filename="receipt.txt"`, "application/octet-stream", "paid"},
+	}
+	if !reflect.DeepEqual(parts, want) {
+		t.Errorf("Encode parts = %+v, want %+v", parts, want)
+	}
+}
+
+// Encodes bodies with a fixed media type, for testing custom encoders.
+type textEncoder struct{}
+
+func (textEncoder) Encode(body interface{}) (io.Reader, string, error) {
+	return strings.NewReader(fmt.Sprint(body)), "text/plain", nil
+}
+
+func TestClient_NewEncodedRequest(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/foo", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		if got, want := r.Header.Get("Content-Type"), "text/plain"; got != want {
+			t.Errorf("Content-Type = %v, want %v", got, want)
+		}
+		if b, _ := ioutil.ReadAll(r.Body); string(b) != "hello" {
+			t.Errorf("Request body = %q, want %q", b, "hello")
+		}
+	})
+
+	req, err := client.NewEncodedRequest("POST", "foo", "hello", textEncoder{})
+	if err != nil {
+		t.Fatalf("NewEncodedRequest returned error: %v", err)
+	}
+	if req.GetBody == nil {
+		t.Errorf("NewEncodedRequest did not make the body replayable")
+	}
+	if _, err := client.Do(req, nil); err != nil {
+		t.Errorf("Do returned error: %v", err)
+	}
+}
+
+func TestClient_NewEncodedRequest_badURL(t *testing.T) {
+	c := NewClient(nil)
+	_, err := c.NewEncodedRequest("POST", ":", nil, FormEncoder)
+	testURLParseError(t, err)
+}
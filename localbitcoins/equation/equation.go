@@ -0,0 +1,304 @@
+// Package equation implements the price equation language of LocalBitcoins
+// advertisements, such as "btc_in_usd*USD_in_EUR*1.03", so that prices can be
+// computed locally from a table of exchange rates.
+//
+// Equations consist of decimal numbers, variables naming exchange rates, the
+// operators +, -, * and /, parentheses, and the functions max and min, which
+// take one or more arguments. Arithmetic is exact.
+package equation
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// SyntaxError reports an equation that cannot be parsed.
+type SyntaxError struct {
+	Pos int    // byte offset of the error in the equation
+	Msg string // description of the error
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("equation: syntax error at column %d: %v", e.Pos+1, e.Msg)
+}
+
+// Equation is a parsed price equation.
+type Equation struct {
+	src  string
+	root node
+}
+
+// Parse parses the price equation s. A *SyntaxError is returned if s is not a
+// valid equation.
+func Parse(s string) (*Equation, error) {
+	tokens, err := Tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	root, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.Kind != EOF {
+		return nil, p.unexpected(t)
+	}
+	return &Equation{src: s, root: root}, nil
+}
+
+// MustParse is like Parse but panics if s cannot be parsed. It simplifies the
+// initialization of equations from constant strings.
+func MustParse(s string) *Equation {
+	e, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+// String returns the source of e.
+func (e *Equation) String() string {
+	return e.src
+}
+
+// Variables returns the names of the variables e refers to, sorted and without
+// duplicates. They are the exchange rates needed to evaluate e.
+func (e *Equation) Variables() []string {
+	seen := make(map[string]bool)
+	e.root.walk(func(n node) {
+		if v, ok := n.(*variable); ok {
+			seen[v.name] = true
+		}
+	})
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Functions supported by equations, with the minimum number of arguments they
+// take.
+var functions = map[string]int{
+	"max": 1,
+	"min": 1,
+}
+
+// A node of the syntax tree of an equation.
+type node interface {
+	pos() int
+	eval(rates Rates) (*big.Rat, error)
+	walk(f func(node))
+}
+
+type number struct {
+	at    int
+	value *big.Rat
+}
+
+type variable struct {
+	at   int
+	name string
+}
+
+type unary struct {
+	at int
+	op TokenKind
+	x  node
+}
+
+type binary struct {
+	at   int
+	op   TokenKind
+	x, y node
+}
+
+type call struct {
+	at   int
+	fn   string
+	args []node
+}
+
+func (n *number) pos() int   { return n.at }
+func (n *variable) pos() int { return n.at }
+func (n *unary) pos() int    { return n.at }
+func (n *binary) pos() int   { return n.at }
+func (n *call) pos() int     { return n.at }
+
+func (n *number) walk(f func(node))   { f(n) }
+func (n *variable) walk(f func(node)) { f(n) }
+
+func (n *unary) walk(f func(node)) {
+	f(n)
+	n.x.walk(f)
+}
+
+func (n *binary) walk(f func(node)) {
+	f(n)
+	n.x.walk(f)
+	n.y.walk(f)
+}
+
+func (n *call) walk(f func(node)) {
+	f(n)
+	for _, a := range n.args {
+		a.walk(f)
+	}
+}
+
+// A recursive descent parser of the grammar
+//
+//	expr    = term { ("+" | "-") term }
+//	term    = factor { ("*" | "/") factor }
+//	factor  = ("+" | "-") factor | primary
+//	primary = number | ident | ident "(" expr { "," expr } ")" | "(" expr ")"
+type parser struct {
+	tokens []Token
+	i      int
+}
+
+func (p *parser) peek() Token {
+	return p.tokens[p.i]
+}
+
+func (p *parser) next() Token {
+	t := p.tokens[p.i]
+	if t.Kind != EOF {
+		p.i++
+	}
+	return t
+}
+
+func (p *parser) expect(kind TokenKind) (Token, error) {
+	t := p.next()
+	if t.Kind != kind {
+		return t, &SyntaxError{
+			Pos: t.Pos,
+			Msg: fmt.Sprintf("expected %v, found %v", kind, t),
+		}
+	}
+	return t, nil
+}
+
+func (p *parser) unexpected(t Token) error {
+	return &SyntaxError{Pos: t.Pos, Msg: fmt.Sprintf("unexpected %v", t)}
+}
+
+func (p *parser) expr() (node, error) {
+	x, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+	for k := p.peek().Kind; k == Plus || k == Minus; k = p.peek().Kind {
+		op := p.next()
+		y, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		x = &binary{at: op.Pos, op: op.Kind, x: x, y: y}
+	}
+	return x, nil
+}
+
+func (p *parser) term() (node, error) {
+	x, err := p.factor()
+	if err != nil {
+		return nil, err
+	}
+	for k := p.peek().Kind; k == Star || k == Slash; k = p.peek().Kind {
+		op := p.next()
+		y, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		x = &binary{at: op.Pos, op: op.Kind, x: x, y: y}
+	}
+	return x, nil
+}
+
+func (p *parser) factor() (node, error) {
+	if k := p.peek().Kind; k == Plus || k == Minus {
+		op := p.next()
+		x, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		return &unary{at: op.Pos, op: op.Kind, x: x}, nil
+	}
+	return p.primary()
+}
+
+func (p *parser) primary() (node, error) {
+	t := p.next()
+	switch t.Kind {
+	case Number:
+		v, ok := new(big.Rat).SetString(t.Text)
+		if !ok {
+			return nil, &SyntaxError{
+				Pos: t.Pos,
+				Msg: fmt.Sprintf("invalid number %q", t.Text),
+			}
+		}
+		return &number{at: t.Pos, value: v}, nil
+	case Ident:
+		if p.peek().Kind != LParen {
+			return &variable{at: t.Pos, name: t.Text}, nil
+		}
+		return p.call(t)
+	case LParen:
+		x, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(RParen); err != nil {
+			return nil, err
+		}
+		return x, nil
+	}
+	return nil, p.unexpected(t)
+}
+
+// Parses the arguments of a call to the function named by fn, whose opening
+// parenthesis is the next token.
+func (p *parser) call(fn Token) (node, error) {
+	name := strings.ToLower(fn.Text)
+	minArgs, ok := functions[name]
+	if !ok {
+		return nil, &SyntaxError{
+			Pos: fn.Pos,
+			Msg: fmt.Sprintf("unknown function %q", fn.Text),
+		}
+	}
+
+	p.next() // (
+	c := &call{at: fn.Pos, fn: name}
+	if p.peek().Kind != RParen {
+		for {
+			arg, err := p.expr()
+			if err != nil {
+				return nil, err
+			}
+			c.args = append(c.args, arg)
+			if p.peek().Kind != Comma {
+				break
+			}
+			p.next()
+		}
+	}
+	if _, err := p.expect(RParen); err != nil {
+		return nil, err
+	}
+
+	if len(c.args) < minArgs {
+		return nil, &SyntaxError{
+			Pos: fn.Pos,
+			Msg: fmt.Sprintf("%v takes at least %d argument(s)", name, minArgs),
+		}
+	}
+	return c, nil
+}
@@ -0,0 +1,68 @@
+package equation
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse_errors(t *testing.T) {
+	tests := []struct {
+		in  string
+		pos int
+		msg string
+	}{
+		{"", 0, "unexpected end of equation"},
+		{"1 +", 3, "unexpected end of equation"},
+		{"(1 + 2", 6, "expected ')', found end of equation"},
+		{"1 2", 2, `unexpected number "2"`},
+		{"foo(1)", 0, `unknown function "foo"`},
+		{"max()", 0, "max takes at least 1 argument(s)"},
+		{"max(1,)", 6, "unexpected ')'"},
+		{"2 * )", 4, "unexpected ')'"},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse(tt.in)
+		serr, ok := err.(*SyntaxError)
+		if !ok {
+			t.Errorf("Parse(%q) returned %#v, want a *SyntaxError", tt.in, err)
+			continue
+		}
+		if serr.Pos != tt.pos || serr.Msg != tt.msg {
+			t.Errorf("Parse(%q) error = %v at %v, want %v at %v", tt.in,
+				serr.Msg, serr.Pos, tt.msg, tt.pos)
+		}
+	}
+}
+
+func TestSyntaxError_Error(t *testing.T) {
+	err := &SyntaxError{Pos: 4, Msg: "unexpected ')'"}
+	if want := "equation: syntax error at column 5: unexpected ')'"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestEquation_Variables(t *testing.T) {
+	e := MustParse("max(bitstampusd, btc_in_usd) * USD_in_EUR / btc_in_usd")
+
+	want := []string{"USD_in_EUR", "bitstampusd", "btc_in_usd"}
+	if got := e.Variables(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Variables returned %v, want %v", got, want)
+	}
+}
+
+func TestEquation_String(t *testing.T) {
+	in := "btc_in_usd * 1.03"
+	if got := MustParse(in).String(); got != in {
+		t.Errorf("String() = %q, want %q", got, in)
+	}
+}
+
+func TestMustParse_panics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MustParse did not panic")
+		}
+	}()
+	MustParse("1 +")
+}
@@ -0,0 +1,121 @@
+package equation
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Rates provides the exchange rates that the variables of an equation refer
+// to.
+type Rates interface {
+	// Rate returns the value of the variable with the provided name, and
+	// whether it is known.
+	Rate(name string) (*big.Rat, bool)
+}
+
+// RateTable is a table of exchange rates by variable name, such as
+// "btc_in_usd" or "USD_in_EUR". It implements Rates.
+type RateTable map[string]*big.Rat
+
+// Rate returns the rate with the provided name.
+func (t RateTable) Rate(name string) (*big.Rat, bool) {
+	v, ok := t[name]
+	return v, ok && v != nil
+}
+
+// Set parses the decimal string value, such as "9123.45", and sets the rate
+// with the provided name to it.
+func (t RateTable) Set(name, value string) error {
+	v, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return fmt.Errorf("equation: invalid rate %q for %v", value, name)
+	}
+	t[name] = v
+	return nil
+}
+
+// EvalError reports an equation that cannot be evaluated, such as one that
+// refers to an unknown rate or divides by zero.
+type EvalError struct {
+	Pos int    // byte offset of the error in the equation
+	Msg string // description of the error
+}
+
+func (e *EvalError) Error() string {
+	return fmt.Sprintf("equation: at column %d: %v", e.Pos+1, e.Msg)
+}
+
+// Eval evaluates e against rates and returns its exact value. An *EvalError
+// is returned if e refers to a rate that rates does not know or divides by
+// zero.
+func (e *Equation) Eval(rates Rates) (*big.Rat, error) {
+	return e.root.eval(rates)
+}
+
+func (n *number) eval(rates Rates) (*big.Rat, error) {
+	return new(big.Rat).Set(n.value), nil
+}
+
+func (n *variable) eval(rates Rates) (*big.Rat, error) {
+	v, ok := rates.Rate(n.name)
+	if !ok {
+		return nil, &EvalError{
+			Pos: n.at,
+			Msg: fmt.Sprintf("unknown rate %q", n.name),
+		}
+	}
+	return new(big.Rat).Set(v), nil
+}
+
+func (n *unary) eval(rates Rates) (*big.Rat, error) {
+	x, err := n.x.eval(rates)
+	if err != nil {
+		return nil, err
+	}
+	if n.op == Minus {
+		x.Neg(x)
+	}
+	return x, nil
+}
+
+func (n *binary) eval(rates Rates) (*big.Rat, error) {
+	x, err := n.x.eval(rates)
+	if err != nil {
+		return nil, err
+	}
+	y, err := n.y.eval(rates)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case Plus:
+		return x.Add(x, y), nil
+	case Minus:
+		return x.Sub(x, y), nil
+	case Star:
+		return x.Mul(x, y), nil
+	case Slash:
+		if y.Sign() == 0 {
+			return nil, &EvalError{Pos: n.at, Msg: "division by zero"}
+		}
+		return x.Quo(x, y), nil
+	}
+	panic("equation: unknown operator " + n.op.String())
+}
+
+func (n *call) eval(rates Rates) (*big.Rat, error) {
+	var result *big.Rat
+	for _, a := range n.args {
+		v, err := a.eval(rates)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil ||
+			(n.fn == "max" && v.Cmp(result) > 0) ||
+			(n.fn == "min" && v.Cmp(result) < 0) {
+			result = v
+		}
+	}
+	return result, nil
+}
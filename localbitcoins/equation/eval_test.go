@@ -0,0 +1,89 @@
+package equation
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestEquation_Eval(t *testing.T) {
+	rates := RateTable{}
+	rates.Set("btc_in_usd", "10000.50")
+	rates.Set("USD_in_EUR", "0.9")
+	rates.Set("bitstampusd", "9990")
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"1.5", "3/2"},
+		{"btc_in_usd*USD_in_EUR*1.03", "9270.4635"},
+		{"1 + 2 * 3", "7"},
+		{"(1 + 2) * 3", "9"},
+		{"10 - 4 - 3", "3"},
+		{"12 / 4 / 3", "1"},
+		{"-2 * -3", "6"},
+		{"+2 - -2", "4"},
+		{"max(btc_in_usd, bitstampusd)", "10000.50"},
+		{"min(btc_in_usd, bitstampusd, 20000)", "9990"},
+		{"MAX(1, 2) * min(3)", "6"},
+		{"1 / 3 * 3", "1"},
+	}
+
+	for _, tt := range tests {
+		e, err := Parse(tt.in)
+		if err != nil {
+			t.Errorf("Parse(%q) returned error: %v", tt.in, err)
+			continue
+		}
+		got, err := e.Eval(rates)
+		if err != nil {
+			t.Errorf("Eval(%q) returned error: %v", tt.in, err)
+			continue
+		}
+		want, _ := new(big.Rat).SetString(tt.want)
+		if got.Cmp(want) != 0 {
+			t.Errorf("Eval(%q) = %v, want %v", tt.in, got.FloatString(4),
+				want.FloatString(4))
+		}
+	}
+}
+
+func TestEquation_Eval_doesNotModifyRates(t *testing.T) {
+	rates := RateTable{}
+	rates.Set("x", "2")
+
+	MustParse("-x * 3 + x").Eval(rates)
+	if rates["x"].Cmp(big.NewRat(2, 1)) != 0 {
+		t.Errorf("Eval modified rate x to %v", rates["x"])
+	}
+}
+
+func TestEquation_Eval_errors(t *testing.T) {
+	tests := []struct {
+		in  string
+		pos int
+		msg string
+	}{
+		{"btc_in_usd * 2", 0, `unknown rate "btc_in_usd"`},
+		{"1 + 2 / (1 - 1)", 6, "division by zero"},
+	}
+
+	for _, tt := range tests {
+		_, err := MustParse(tt.in).Eval(RateTable{})
+		eerr, ok := err.(*EvalError)
+		if !ok {
+			t.Errorf("Eval(%q) returned %#v, want an *EvalError", tt.in, err)
+			continue
+		}
+		if eerr.Pos != tt.pos || eerr.Msg != tt.msg {
+			t.Errorf("Eval(%q) error = %v at %v, want %v at %v", tt.in,
+				eerr.Msg, eerr.Pos, tt.msg, tt.pos)
+		}
+	}
+}
+
+func TestRateTable_Set_invalid(t *testing.T) {
+	if err := (RateTable{}).Set("x", "abc"); err == nil {
+		t.Errorf("Set returned no error for an invalid rate")
+	}
+}
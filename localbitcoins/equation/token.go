@@ -0,0 +1,123 @@
+package equation
+
+import "fmt"
+
+// TokenKind is the kind of a token of an equation.
+type TokenKind int
+
+// Token kinds of the equation language.
+const (
+	EOF    TokenKind = iota // end of the equation
+	Number                  // decimal number, such as 1.03
+	Ident                   // variable or function name, such as btc_in_usd
+	Plus                    // +
+	Minus                   // -
+	Star                    // *
+	Slash                   // /
+	LParen                  // (
+	RParen                  // )
+	Comma                   // ,
+)
+
+var tokenKindNames = map[TokenKind]string{
+	EOF:    "end of equation",
+	Number: "number",
+	Ident:  "identifier",
+	Plus:   "'+'",
+	Minus:  "'-'",
+	Star:   "'*'",
+	Slash:  "'/'",
+	LParen: "'('",
+	RParen: "')'",
+	Comma:  "','",
+}
+
+func (k TokenKind) String() string {
+	if name, ok := tokenKindNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("TokenKind(%d)", int(k))
+}
+
+// Token is a token of an equation.
+type Token struct {
+	Kind TokenKind
+	Text string // source text of the token
+	Pos  int    // byte offset of the token in the equation
+}
+
+func (t Token) String() string {
+	switch t.Kind {
+	case Number, Ident:
+		return fmt.Sprintf("%v %q", t.Kind, t.Text)
+	}
+	return t.Kind.String()
+}
+
+var punctuation = map[byte]TokenKind{
+	'+': Plus,
+	'-': Minus,
+	'*': Star,
+	'/': Slash,
+	'(': LParen,
+	')': RParen,
+	',': Comma,
+}
+
+// Tokenize splits the equation s into tokens, skipping whitespace. The last
+// token is always of kind EOF. A *SyntaxError is returned if s contains a
+// character that cannot start a token.
+func Tokenize(s string) ([]Token, error) {
+	var tokens []Token
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case isSpace(c):
+			i++
+		case isDigit(c) || c == '.':
+			start := i
+			for i < len(s) && isDigit(s[i]) {
+				i++
+			}
+			if i < len(s) && s[i] == '.' {
+				i++
+				for i < len(s) && isDigit(s[i]) {
+					i++
+				}
+			}
+			if s[start:i] == "." {
+				return nil, &SyntaxError{Pos: start, Msg: "invalid number \".\""}
+			}
+			tokens = append(tokens, Token{Number, s[start:i], start})
+		case isLetter(c):
+			start := i
+			for i < len(s) && (isLetter(s[i]) || isDigit(s[i])) {
+				i++
+			}
+			tokens = append(tokens, Token{Ident, s[start:i], start})
+		default:
+			kind, ok := punctuation[c]
+			if !ok {
+				return nil, &SyntaxError{
+					Pos: i,
+					Msg: fmt.Sprintf("unexpected character %q", c),
+				}
+			}
+			tokens = append(tokens, Token{kind, s[i : i+1], i})
+			i++
+		}
+	}
+	return append(tokens, Token{EOF, "", len(s)}), nil
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c == '_'
+}
@@ -0,0 +1,70 @@
+package equation
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tokens, err := Tokenize("max(btc_in_usd * 1.03, .5)- x2")
+	if err != nil {
+		t.Fatalf("Tokenize returned error: %v", err)
+	}
+
+	want := []Token{
+		{Ident, "max", 0},
+		{LParen, "(", 3},
+		{Ident, "btc_in_usd", 4},
+		{Star, "*", 15},
+		{Number, "1.03", 17},
+		{Comma, ",", 21},
+		{Number, ".5", 23},
+		{RParen, ")", 25},
+		{Minus, "-", 26},
+		{Ident, "x2", 28},
+		{EOF, "", 30},
+	}
+	if !reflect.DeepEqual(tokens, want) {
+		t.Errorf("Tokenize returned %v, want %v", tokens, want)
+	}
+}
+
+func TestTokenize_errors(t *testing.T) {
+	tests := []struct {
+		in  string
+		pos int
+	}{
+		{"1 + $", 4},
+		{"btc_in_usd * .", 13},
+		{"1 ^ 2", 2},
+	}
+
+	for _, tt := range tests {
+		_, err := Tokenize(tt.in)
+		serr, ok := err.(*SyntaxError)
+		if !ok {
+			t.Errorf("Tokenize(%q) returned %#v, want a *SyntaxError", tt.in, err)
+			continue
+		}
+		if serr.Pos != tt.pos {
+			t.Errorf("Tokenize(%q) error at %v, want %v", tt.in, serr.Pos, tt.pos)
+		}
+	}
+}
+
+func TestToken_String(t *testing.T) {
+	tests := []struct {
+		token Token
+		want  string
+	}{
+		{Token{Ident, "btc_in_usd", 0}, `identifier "btc_in_usd"`},
+		{Token{Comma, ",", 0}, "','"},
+		{Token{EOF, "", 0}, "end of equation"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.token.String(); got != tt.want {
+			t.Errorf("%#v.String() = %q, want %q", tt.token, got, tt.want)
+		}
+	}
+}
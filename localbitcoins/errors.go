@@ -0,0 +1,147 @@
+package localbitcoins
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// ErrorCode is an error code returned by the LocalBitcoins API in the
+// error_code field of an error object.
+type ErrorCode int
+
+// Error codes documented by the LocalBitcoins API.
+const (
+	ErrorCodeInternal            ErrorCode = 1
+	ErrorCodeNotAuthenticated    ErrorCode = 2
+	ErrorCodeInvalidAPIKey       ErrorCode = 3
+	ErrorCodePermissionDenied    ErrorCode = 4
+	ErrorCodeInvalidParameters   ErrorCode = 9
+	ErrorCodeNotFound            ErrorCode = 12
+	ErrorCodeAdNotFound          ErrorCode = 16
+	ErrorCodeInsufficientBalance ErrorCode = 19
+	ErrorCodeInvalidPIN          ErrorCode = 22
+	ErrorCodeInvalidSignature    ErrorCode = 41
+	ErrorCodeNonceTooSmall       ErrorCode = 42
+)
+
+var errorCodeNames = map[ErrorCode]string{
+	ErrorCodeInternal:            "internal error",
+	ErrorCodeNotAuthenticated:    "not authenticated",
+	ErrorCodeInvalidAPIKey:       "invalid API key",
+	ErrorCodePermissionDenied:    "permission denied",
+	ErrorCodeInvalidParameters:   "invalid parameters",
+	ErrorCodeNotFound:            "not found",
+	ErrorCodeAdNotFound:          "ad not found",
+	ErrorCodeInsufficientBalance: "insufficient balance",
+	ErrorCodeInvalidPIN:          "invalid PIN",
+	ErrorCodeInvalidSignature:    "invalid HMAC signature",
+	ErrorCodeNonceTooSmall:       "nonce too small",
+}
+
+func (c ErrorCode) String() string {
+	if name, ok := errorCodeNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("ErrorCode(%d)", int(c))
+}
+
+// Sentinel errors for the documented error codes. API errors match them with
+// errors.Is when their codes are equal:
+//
+//	if errors.Is(err, localbitcoins.ErrInvalidPIN) {
+//		// ask for the PIN again
+//	}
+var (
+	ErrInternal            = newCodeError(ErrorCodeInternal)
+	ErrNotAuthenticated    = newCodeError(ErrorCodeNotAuthenticated)
+	ErrInvalidAPIKey       = newCodeError(ErrorCodeInvalidAPIKey)
+	ErrPermissionDenied    = newCodeError(ErrorCodePermissionDenied)
+	ErrInvalidParameters   = newCodeError(ErrorCodeInvalidParameters)
+	ErrNotFound            = newCodeError(ErrorCodeNotFound)
+	ErrAdNotFound          = newCodeError(ErrorCodeAdNotFound)
+	ErrInsufficientBalance = newCodeError(ErrorCodeInsufficientBalance)
+	ErrInvalidPIN          = newCodeError(ErrorCodeInvalidPIN)
+	ErrInvalidSignature    = newCodeError(ErrorCodeInvalidSignature)
+	ErrNonceTooSmall       = newCodeError(ErrorCodeNonceTooSmall)
+)
+
+func newCodeError(c ErrorCode) *Error {
+	return &Error{Message: c.String(), Code: c}
+}
+
+// Maximum number of bytes of an error response body that are retained.
+const maxErrorBodySize = 4096
+
+// Maximum number of bytes of an error response body that are read to decode
+// the error object it may hold.
+const maxErrorDecodeSize = 1 << 20
+
+// UpstreamError reports a failed response whose body is not a LocalBitcoins
+// API error, which usually means it was produced by a proxy or CDN in front of
+// the API rather than by the API itself. Examples are HTML error pages and
+// Cloudflare challenges.
+type UpstreamError struct {
+	Response *http.Response
+
+	// Raw body and media type of the response. The body is truncated to
+	// maxErrorBodySize bytes.
+	Body        []byte
+	ContentType string
+}
+
+func (e *UpstreamError) Error() string {
+	msg := fmt.Sprintf("%v %v: %d - upstream error",
+		e.Response.Request.Method, e.Response.Request.URL,
+		e.Response.StatusCode)
+	if e.ContentType != "" {
+		msg += " (" + e.ContentType + ")"
+	}
+	if e.IsChallenge() {
+		msg += ": Cloudflare challenge"
+	} else if snippet := bodySnippet(e.Body); snippet != "" {
+		msg += ": " + snippet
+	}
+	return msg
+}
+
+// IsChallenge reports whether the response is a Cloudflare challenge page,
+// which is served instead of the API response when Cloudflare suspects the
+// client of being abusive.
+func (e *UpstreamError) IsChallenge() bool {
+	h := e.Response.Header
+	fromCloudflare := h.Get("Cf-Ray") != "" ||
+		strings.EqualFold(h.Get("Server"), "cloudflare")
+	if !fromCloudflare || e.ContentType != "text/html" {
+		return false
+	}
+	switch e.Response.StatusCode {
+	case http.StatusForbidden, http.StatusServiceUnavailable:
+		return true
+	}
+	return false
+}
+
+// Returns the media type of a response body, as given by the Content-Type
+// header or, if the header is missing or invalid, as sniffed from the body.
+func detectContentType(header string, body []byte) string {
+	if mt, _, err := mime.ParseMediaType(header); err == nil {
+		return mt
+	}
+	if len(body) == 0 {
+		return ""
+	}
+	mt, _, _ := mime.ParseMediaType(http.DetectContentType(body))
+	return mt
+}
+
+// Returns a short, single line excerpt of body suitable for error messages.
+func bodySnippet(body []byte) string {
+	const max = 100
+	s := strings.Join(strings.Fields(string(body)), " ")
+	if len(s) > max {
+		s = s[:max] + "..."
+	}
+	return s
+}
@@ -0,0 +1,94 @@
+package localbitcoins
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestErrorCode_String(t *testing.T) {
+	if got, want := ErrorCodeNonceTooSmall.String(), "nonce too small"; got != want {
+		t.Errorf("ErrorCodeNonceTooSmall.String() = %v, want %v", got, want)
+	}
+	if got, want := ErrorCode(999).String(), "ErrorCode(999)"; got != want {
+		t.Errorf("ErrorCode(999).String() = %v, want %v", got, want)
+	}
+}
+
+func TestErrorResponse_Is(t *testing.T) {
+	res := &http.Response{
+		Request:    &http.Request{},
+		StatusCode: http.StatusBadRequest,
+		Body: ioutil.NopCloser(strings.NewReader(fmt.Sprintf(`{
+      "error": {"message": "Invalid PIN", "error_code": %d}}`, ErrorCodeInvalidPIN))),
+	}
+	err := CheckResponse(res)
+
+	if !errors.Is(err, ErrInvalidPIN) {
+		t.Errorf("errors.Is(%v, ErrInvalidPIN) = false, want true", err)
+	}
+	if errors.Is(err, ErrInsufficientBalance) {
+		t.Errorf("errors.Is(%v, ErrInsufficientBalance) = true, want false", err)
+	}
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.Message != "Invalid PIN" {
+		t.Errorf("errors.As(%v) = %+v, want the error object of the response", err, apiErr)
+	}
+}
+
+func TestErrorResponse_fieldErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/ad-create/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{
+      "error": {
+        "message": "Invalid form data.",
+        "error_code": %d,
+        "errors": {"price_equation": "Invalid equation", "max_amount": "Too large"}
+      }
+    }`, ErrorCodeInvalidParameters)
+	})
+
+	_, _, err := client.Ads.Create(&AdOptions{})
+	if !errors.Is(err, ErrInvalidParameters) {
+		t.Errorf("Ads.Create returned %v, want ErrInvalidParameters", err)
+	}
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Ads.Create returned %#v, want an error wrapping *Error", err)
+	}
+	want := map[string]string{
+		"price_equation": "Invalid equation",
+		"max_amount":     "Too large",
+	}
+	if !reflect.DeepEqual(apiErr.Errors, want) {
+		t.Errorf("Error.Errors = %v, want %v", apiErr.Errors, want)
+	}
+}
+
+func TestRateLimitError_Unwrap(t *testing.T) {
+	err := &RateLimitError{Err: Error{Code: ErrorCodeNonceTooSmall}}
+	if !errors.Is(err, ErrNonceTooSmall) {
+		t.Errorf("errors.Is(%#v, ErrNonceTooSmall) = false, want true", err)
+	}
+}
+
+func TestRateLimitError_Unwrap_noErrorObject(t *testing.T) {
+	err := &RateLimitError{RetryAfter: time.Second}
+	if u := err.Unwrap(); u != nil {
+		t.Errorf("Unwrap returned %#v, want nil", u)
+	}
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		t.Errorf("errors.As found an error object: %#v", apiErr)
+	}
+}
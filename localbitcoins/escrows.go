@@ -1,6 +1,11 @@
 package localbitcoins
 
-import "time"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
 
 // EscrowsService handles all escrow-related communications with the
 // LocalBitcoins API.
@@ -14,8 +19,8 @@ type Escrow struct {
 	BuyerUsername         *string    `json:"buyer_username,omitempty"`
 	ReferenceCode         *string    `json:"reference_code,omitempty"`
 	Currency              *string    `json:"currency,omitempty"`
-	Amount                *float64   `json:"amount,string,omitempty"`
-	AmountBTC             *float64   `json:"amount_btc,string,omitempty"`
+	Amount                *Amount    `json:"amount,omitempty"`
+	AmountBTC             *Amount    `json:"amount_btc,omitempty"`
 	ExchangeRateUpdatedAt *time.Time `json:"exchange_rate_updated_at,omitempty"`
 
 	releaseUrl *string
@@ -42,7 +47,33 @@ type releaseUrlMiddleman struct {
 	ReleaseUrl *string `json:"release_url,omitempty"`
 }
 
+// MissingActionError is returned when an operation requires an action URL that
+// the LocalBitcoins API did not provide for the object in question.
+type MissingActionError struct {
+	Action string // name of the missing action, such as "release"
+}
+
+func (e *MissingActionError) Error() string {
+	return fmt.Sprintf("localbitcoins: no %v action available", e.Action)
+}
+
+// ErrEscrowNotFound is returned by ReleaseByReferenceCode when no open escrow
+// has the provided reference code.
+var ErrEscrowNotFound = errors.New("localbitcoins: escrow not found")
+
+// Escrow release middleman used strictly for unmarshaling the result of
+// releasing an escrow.
+type escrowReleaseMiddleman struct {
+	Message *string `json:"message,omitempty"`
+}
+
+// List lists the open escrows of the authenticated account.
 func (s *EscrowsService) List() ([]*Escrow, *Response, error) {
+	return s.ListContext(context.Background())
+}
+
+// ListContext is like List but uses ctx to control its requests.
+func (s *EscrowsService) ListContext(ctx context.Context) ([]*Escrow, *Response, error) {
 	req, err := s.client.NewRequest("GET", "/api/escrows/", nil)
 	if err != nil {
 		return nil, nil, err
@@ -50,7 +81,7 @@ func (s *EscrowsService) List() ([]*Escrow, *Response, error) {
 
 	middleman := new(escrowListMiddleman)
 	respMiddleman := &ResponseData{Data: middleman}
-	resp, err := s.client.Do(req, respMiddleman)
+	resp, err := s.client.DoContext(ctx, req, respMiddleman)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -59,8 +90,67 @@ func (s *EscrowsService) List() ([]*Escrow, *Response, error) {
 
 	for i, e := range middleman.Escrows {
 		escrows[i] = e.Escrow
-		escrows[i].releaseUrl = e.ReleaseUrlMiddleman.ReleaseUrl
+		if e.ReleaseUrlMiddleman != nil {
+			escrows[i].releaseUrl = e.ReleaseUrlMiddleman.ReleaseUrl
+		}
 	}
 
 	return escrows, resp, err
 }
+
+// Release releases the provided escrow using the release URL returned by the
+// LocalBitcoins API when the escrow was listed. The message returned by the
+// API is passed back to the caller. If the escrow has no release action, a
+// *MissingActionError is returned.
+func (s *EscrowsService) Release(escrow *Escrow) (string, *Response, error) {
+	return s.ReleaseContext(context.Background(), escrow)
+}
+
+// ReleaseContext is like Release but uses ctx to control its requests.
+func (s *EscrowsService) ReleaseContext(ctx context.Context, escrow *Escrow) (string, *Response, error) {
+	if escrow == nil || escrow.releaseUrl == nil || *escrow.releaseUrl == "" {
+		return "", nil, &MissingActionError{Action: "release"}
+	}
+
+	req, err := s.client.NewRequest("POST", *escrow.releaseUrl, nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	middleman := new(escrowReleaseMiddleman)
+	respMiddleman := &ResponseData{Data: middleman}
+	resp, err := s.client.DoContext(ctx, req, respMiddleman)
+	if err != nil {
+		return "", resp, err
+	}
+
+	var msg string
+	if middleman.Message != nil {
+		msg = *middleman.Message
+	}
+	return msg, resp, err
+}
+
+// ReleaseByReferenceCode looks up the open escrow with the provided reference
+// code and releases it. If no such escrow exists, ErrEscrowNotFound is
+// returned.
+func (s *EscrowsService) ReleaseByReferenceCode(code string) (string, *Response, error) {
+	return s.ReleaseByReferenceCodeContext(context.Background(), code)
+}
+
+// ReleaseByReferenceCodeContext is like ReleaseByReferenceCode but uses ctx to
+// control its requests.
+func (s *EscrowsService) ReleaseByReferenceCodeContext(ctx context.Context, code string) (string, *Response, error) {
+	escrows, resp, err := s.ListContext(ctx)
+	if err != nil {
+		return "", resp, err
+	}
+
+	for _, e := range escrows {
+		if e.ReferenceCode != nil && *e.ReferenceCode == code {
+			return s.ReleaseContext(ctx, e)
+		}
+	}
+
+	return "", resp, ErrEscrowNotFound
+}
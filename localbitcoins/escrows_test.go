@@ -37,3 +37,70 @@ func TestEscrowsService_List(t *testing.T) {
 		t.Errorf("Escrows.List returned %+v, want %+v", escrow, want)
 	}
 }
+
+func TestEscrowsService_Release(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/escrow_release/1/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, `{"data":{"message":"The escrow has been released."}}`)
+	})
+
+	e := &Escrow{releaseUrl: String("/api/escrow_release/1/")}
+	msg, _, err := client.Escrows.Release(e)
+	if err != nil {
+		t.Errorf("Escrows.Release returned error: %v", err)
+	}
+
+	if want := "The escrow has been released."; msg != want {
+		t.Errorf("Escrows.Release returned %q, want %q", msg, want)
+	}
+}
+
+func TestEscrowsService_Release_noAction(t *testing.T) {
+	_, _, err := NewClient(nil).Escrows.Release(&Escrow{})
+	if err, ok := err.(*MissingActionError); !ok || err.Action != "release" {
+		t.Errorf("Expected a MissingActionError; got %#v.", err)
+	}
+}
+
+func TestEscrowsService_ReleaseByReferenceCode(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/escrows/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{
+      "data":{
+        "escrow_list":[
+        {
+          "data":{"reference_code":"L1"},
+          "actions":{"release_url":"/api/escrow_release/1/"}
+        },
+        {
+          "data":{"reference_code":"L2"},
+          "actions":{"release_url":"/api/escrow_release/2/"}
+        }
+        ]
+      }
+    }`)
+	})
+	mux.HandleFunc("/api/escrow_release/2/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, `{"data":{"message":"released"}}`)
+	})
+
+	msg, _, err := client.Escrows.ReleaseByReferenceCode("L2")
+	if err != nil {
+		t.Errorf("Escrows.ReleaseByReferenceCode returned error: %v", err)
+	}
+	if want := "released"; msg != want {
+		t.Errorf("Escrows.ReleaseByReferenceCode returned %q, want %q", msg, want)
+	}
+
+	_, _, err = client.Escrows.ReleaseByReferenceCode("L3")
+	if err != ErrEscrowNotFound {
+		t.Errorf("Expected ErrEscrowNotFound; got %#v.", err)
+	}
+}
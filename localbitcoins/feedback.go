@@ -0,0 +1,131 @@
+package localbitcoins
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// FeedbackService handles all feedback-related communications with the
+// LocalBitcoins API.
+type FeedbackService struct {
+	client *Client
+}
+
+// FeedbackType is the kind of feedback left for a user.
+type FeedbackType string
+
+// Feedback types supported by the LocalBitcoins API.
+const (
+	FeedbackTrust                FeedbackType = "trust"
+	FeedbackPositive             FeedbackType = "positive"
+	FeedbackNeutral              FeedbackType = "neutral"
+	FeedbackBlock                FeedbackType = "block"
+	FeedbackBlockWithoutFeedback FeedbackType = "block_without_feedback"
+)
+
+// Feedback represents a piece of feedback left for a user, as returned by the
+// LocalBitcoins API.
+type Feedback struct {
+	Feedback  *FeedbackType `json:"feedback,omitempty"`
+	Message   *string       `json:"msg,omitempty"`
+	CreatedAt *time.Time    `json:"created_at,omitempty"`
+
+	// From is the account that left the feedback.
+	From *Account `json:"from_user,omitempty"`
+}
+
+func (f Feedback) String() string {
+	return Stringify(f)
+}
+
+// FeedbackOptions specifies the parameters to the FeedbackService.Leave
+// method.
+type FeedbackOptions struct {
+	Feedback FeedbackType `url:"feedback"`
+	Message  string       `url:"msg,omitempty"`
+}
+
+// Feedback list middleman used strictly for unmarshaling the API response.
+type feedbackListMiddleman struct {
+	Feedback []*Feedback `json:"feedback_list,omitempty"`
+}
+
+// Middleman used strictly for unmarshaling the result of leaving feedback.
+type feedbackResultMiddleman struct {
+	Message *string `json:"message,omitempty"`
+}
+
+// Leave leaves feedback for the user with the provided username, replacing any
+// feedback previously left for them by the authenticated account. The message
+// returned by the API is passed back to the caller.
+func (s *FeedbackService) Leave(username string, opt *FeedbackOptions) (string, *Response, error) {
+	return s.LeaveContext(context.Background(), username, opt)
+}
+
+// LeaveContext is like Leave but uses ctx to control its requests.
+func (s *FeedbackService) LeaveContext(ctx context.Context, username string, opt *FeedbackOptions) (string, *Response, error) {
+	u := fmt.Sprintf("api/feedback/%v/", url.PathEscape(username))
+	req, err := s.client.NewFormRequest("POST", u, opt)
+	if err != nil {
+		return "", nil, err
+	}
+
+	result := new(feedbackResultMiddleman)
+	respMiddleman := &ResponseData{Data: result}
+	resp, err := s.client.DoContext(ctx, req, respMiddleman)
+	if err != nil {
+		return "", resp, err
+	}
+
+	var msg string
+	if result.Message != nil {
+		msg = *result.Message
+	}
+	return msg, resp, err
+}
+
+// List lists the feedback received by the user with the provided username.
+// Passing an empty string will list the feedback received by the authenticated
+// account.
+func (s *FeedbackService) List(username string) ([]*Feedback, *Response, error) {
+	return s.ListContext(context.Background(), username)
+}
+
+// ListContext is like List but uses ctx to control its requests.
+func (s *FeedbackService) ListContext(ctx context.Context, username string) ([]*Feedback, *Response, error) {
+	return s.list(ctx, feedbackListURL(username))
+}
+
+// ListIterator returns an Iterator over the feedback received by the user with
+// the provided username, fetching every page of the list. Passing an empty
+// string will iterate over the feedback received by the authenticated account.
+func (s *FeedbackService) ListIterator(username string) *Iterator[*Feedback] {
+	return NewIterator(feedbackListURL(username), s.list)
+}
+
+// Returns the URL listing the feedback received by username, or by the
+// authenticated account if username is empty.
+func feedbackListURL(username string) string {
+	if username == "" {
+		return "api/myself/feedback/"
+	}
+	return fmt.Sprintf("api/feedback/%v/", url.PathEscape(username))
+}
+
+func (s *FeedbackService) list(ctx context.Context, u string) ([]*Feedback, *Response, error) {
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	middleman := new(feedbackListMiddleman)
+	respMiddleman := &ResponseData{Data: middleman}
+	resp, err := s.client.DoContext(ctx, req, respMiddleman)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return middleman.Feedback, resp, err
+}
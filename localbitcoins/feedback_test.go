@@ -0,0 +1,160 @@
+package localbitcoins
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFeedback_marshall(t *testing.T) {
+	testJSONMarshal(t, &Feedback{}, "{}")
+
+	ft := FeedbackTrust
+	f := &Feedback{
+		Feedback:  &ft,
+		Message:   String("fast trade"),
+		CreatedAt: &time.Time{},
+		From:      &Account{Username: String("foo")},
+	}
+	want := `{
+    "feedback": "trust",
+    "msg": "fast trade",
+    "created_at": "0001-01-01T00:00:00Z",
+    "from_user": {"username": "foo"}
+  }`
+	testJSONMarshal(t, f, want)
+}
+
+func TestFeedbackService_Leave(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/feedback/foo/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testFormValues(t, r, values{"feedback": "block", "msg": "no show"})
+		fmt.Fprint(w, `{"data":{"message":"Feedback changed."}}`)
+	})
+
+	opt := &FeedbackOptions{Feedback: FeedbackBlock, Message: "no show"}
+	msg, _, err := client.Feedback.Leave("foo", opt)
+	if err != nil {
+		t.Errorf("Feedback.Leave returned error: %v", err)
+	}
+
+	if want := "Feedback changed."; msg != want {
+		t.Errorf("Feedback.Leave returned %q, want %q", msg, want)
+	}
+}
+
+func TestFeedbackService_Leave_withoutMessage(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/feedback/foo/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testFormValues(t, r, values{"feedback": "neutral"})
+		fmt.Fprint(w, `{"data":{"message":"Feedback changed."}}`)
+	})
+
+	opt := &FeedbackOptions{Feedback: FeedbackNeutral}
+	if _, _, err := client.Feedback.Leave("foo", opt); err != nil {
+		t.Errorf("Feedback.Leave returned error: %v", err)
+	}
+}
+
+func TestFeedbackService_List(t *testing.T) {
+	tests := []struct {
+		username string
+		path     string
+	}{
+		{"foo", "/api/feedback/foo/"},
+		{"", "/api/myself/feedback/"},
+	}
+
+	for _, tt := range tests {
+		setup()
+
+		mux.HandleFunc(tt.path, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			fmt.Fprint(w, `{
+        "data":{
+          "feedback_list":[
+            {"feedback":"positive","msg":"ok","from_user":{"username":"bar"}}
+          ]
+        }
+      }`)
+		})
+
+		feedback, _, err := client.Feedback.List(tt.username)
+		if err != nil {
+			t.Errorf("Feedback.List(%q) returned error: %v", tt.username, err)
+		}
+
+		ft := FeedbackPositive
+		want := []*Feedback{{
+			Feedback: &ft,
+			Message:  String("ok"),
+			From:     &Account{Username: String("bar")},
+		}}
+		if !reflect.DeepEqual(feedback, want) {
+			t.Errorf("Feedback.List(%q) returned %+v, want %+v", tt.username,
+				feedback, want)
+		}
+
+		teardown()
+	}
+}
+
+func TestFeedbackService_ListIterator(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/feedback/foo/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `{"data":{"feedback_list":[{"feedback":"neutral"}]}}`)
+			return
+		}
+		fmt.Fprintf(w, `{
+      "data":{"feedback_list":[{"feedback":"trust"}]},
+      "pagination":{"next":"%v/api/feedback/foo/?page=2"}
+    }`, server.URL)
+	})
+
+	feedback, err := client.Feedback.ListIterator("foo").All()
+	if err != nil {
+		t.Errorf("Feedback.ListIterator returned error: %v", err)
+	}
+
+	if len(feedback) != 2 {
+		t.Fatalf("Feedback.ListIterator returned %v items, want 2", len(feedback))
+	}
+	if got := *feedback[1].Feedback; got != FeedbackNeutral {
+		t.Errorf("Feedback.ListIterator second item = %v, want %v", got,
+			FeedbackNeutral)
+	}
+}
+
+func TestFeedbackService_escapesUsername(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var paths []string
+	mux.HandleFunc("/api/feedback/", func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.EscapedPath())
+		fmt.Fprint(w, `{"data":{}}`)
+	})
+
+	client.Feedback.Leave("a/b?c#d", &FeedbackOptions{Feedback: FeedbackTrust})
+	client.Feedback.List("a/b?c#d")
+
+	want := []string{
+		"/api/feedback/a%2Fb%3Fc%23d/",
+		"/api/feedback/a%2Fb%3Fc%23d/",
+	}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("Request paths: %v, want %v", paths, want)
+	}
+}
@@ -0,0 +1,125 @@
+package localbitcoins
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HMACTransport is an http.RoundTripper that authenticates requests to the
+// LocalBitcoins API using an HMAC key and secret, as described at
+// https://localbitcoins.com/api-docs/#hmac-authentication.
+//
+// Each request is signed with a nonce that is guaranteed to be greater than the
+// nonce of every request previously signed by the same HMACTransport, so a
+// single HMACTransport may be safely shared by multiple goroutines.
+type HMACTransport struct {
+	// HMAC key and secret, as created at https://localbitcoins.com/accounts/api/.
+	Key    string
+	Secret string
+
+	// Transport is the underlying http.RoundTripper used to make requests. If
+	// nil, http.DefaultTransport is used.
+	Transport http.RoundTripper
+
+	mu        sync.Mutex
+	lastNonce int64
+}
+
+// NewHMACClient returns a new LocalBitcoins API client that authenticates its
+// requests using the provided HMAC key and secret.
+func NewHMACClient(key, secret string) *Client {
+	t := &HMACTransport{Key: key, Secret: secret}
+	return NewClient(t.Client())
+}
+
+// Client returns an *http.Client that makes requests which are authenticated
+// using HMAC.
+func (t *HMACTransport) Client() *http.Client {
+	return &http.Client{Transport: t}
+}
+
+// RoundTrip signs the request and passes it to the underlying transport. The
+// provided request is not modified.
+func (t *HMACTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var params string
+	if req.Body != nil {
+		body, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req = cloneRequest(req)
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		if isFormRequest(req) {
+			params = string(body)
+		}
+	} else {
+		req = cloneRequest(req)
+	}
+	if req.Method == "GET" || req.Method == "HEAD" {
+		params = req.URL.RawQuery
+	}
+
+	nonce := strconv.FormatInt(t.nonce(), 10)
+	req.Header.Set("Apiauth-Key", t.Key)
+	req.Header.Set("Apiauth-Nonce", nonce)
+	req.Header.Set("Apiauth-Signature",
+		t.sign(nonce, req.URL.EscapedPath(), params))
+
+	return t.transport().RoundTrip(req)
+}
+
+// Computes the HMAC signature of a request with the given nonce, path and
+// urlencoded parameters.
+func (t *HMACTransport) sign(nonce, path, params string) string {
+	mac := hmac.New(sha256.New, []byte(t.Secret))
+	mac.Write([]byte(nonce + t.Key + path + params))
+	return strings.ToUpper(hex.EncodeToString(mac.Sum(nil)))
+}
+
+// Returns a new nonce, which is always greater than the previous one.
+func (t *HMACTransport) nonce() int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	n := time.Now().UnixNano() / int64(time.Microsecond)
+	if n <= t.lastNonce {
+		n = t.lastNonce + 1
+	}
+	t.lastNonce = n
+	return n
+}
+
+func (t *HMACTransport) transport() http.RoundTripper {
+	if t.Transport != nil {
+		return t.Transport
+	}
+	return http.DefaultTransport
+}
+
+// Reports whether the body of req is urlencoded form data, which LocalBitcoins
+// includes in the HMAC signature.
+func isFormRequest(req *http.Request) bool {
+	ct := req.Header.Get("Content-Type")
+	return ct == "" || strings.HasPrefix(ct, "application/x-www-form-urlencoded")
+}
+
+// Returns a shallow copy of r with a deep copy of its headers, so that the
+// headers may be modified without affecting the original request.
+func cloneRequest(r *http.Request) *http.Request {
+	r2 := new(http.Request)
+	*r2 = *r
+	r2.Header = make(http.Header, len(r.Header))
+	for k, s := range r.Header {
+		r2.Header[k] = append([]string(nil), s...)
+	}
+	return r2
+}
@@ -0,0 +1,126 @@
+package localbitcoins
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Verifies the HMAC headers of r against key and secret, returning the
+// signed nonce.
+func testHMACSignature(t *testing.T, r *http.Request, key, secret string) int64 {
+	if got := r.Header.Get("Apiauth-Key"); got != key {
+		t.Errorf("Apiauth-Key = %v, want %v", got, key)
+	}
+
+	nonce := r.Header.Get("Apiauth-Nonce")
+	n, err := strconv.ParseInt(nonce, 10, 64)
+	if err != nil {
+		t.Errorf("Apiauth-Nonce %q is not an integer", nonce)
+	}
+
+	params := r.URL.RawQuery
+	if r.Method == "POST" {
+		body, _ := ioutil.ReadAll(r.Body)
+		params = string(body)
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(nonce + key + r.URL.Path + params))
+	want := strings.ToUpper(hex.EncodeToString(mac.Sum(nil)))
+	if got := r.Header.Get("Apiauth-Signature"); got != want {
+		t.Errorf("Apiauth-Signature = %v, want %v", got, want)
+	}
+	return n
+}
+
+func TestNewHMACClient(t *testing.T) {
+	c := NewHMACClient("k", "s")
+
+	tr, ok := c.client.Transport.(*HMACTransport)
+	if !ok {
+		t.Fatalf("NewHMACClient transport = %#v, want *HMACTransport", c.client.Transport)
+	}
+	if tr.Key != "k" || tr.Secret != "s" {
+		t.Errorf("NewHMACClient key and secret = %v, %v, want k, s", tr.Key, tr.Secret)
+	}
+}
+
+func TestHMACTransport_get(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client = NewHMACClient("key", "secret")
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	mux.HandleFunc("/api/account_info/foo/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testHMACSignature(t, r, "key", "secret")
+		fmt.Fprint(w, `{"data":{"username":"foo"}}`)
+	})
+
+	if _, _, err := client.Accounts.Get("foo"); err != nil {
+		t.Errorf("Accounts.Get returned error: %v", err)
+	}
+}
+
+func TestHMACTransport_post(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/foo/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testHMACSignature(t, r, "key", "secret")
+	})
+
+	tr := &HMACTransport{Key: "key", Secret: "secret"}
+	req, _ := http.NewRequest("POST", server.URL+"/api/foo/", strings.NewReader("a=1&b=2"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := tr.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip returned error: %v", err)
+	}
+	resp.Body.Close()
+
+	if req.Header.Get("Apiauth-Signature") != "" {
+		t.Errorf("RoundTrip modified the original request")
+	}
+}
+
+func TestHMACTransport_nonce(t *testing.T) {
+	tr := &HMACTransport{}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	seen := make(map[int64]bool)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			last := int64(0)
+			for j := 0; j < 100; j++ {
+				n := tr.nonce()
+				if n <= last {
+					t.Errorf("nonce() = %v, want greater than %v", n, last)
+				}
+				last = n
+
+				mu.Lock()
+				if seen[n] {
+					t.Errorf("nonce() returned %v more than once", n)
+				}
+				seen[n] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -1,13 +1,15 @@
 package localbitcoins
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"reflect"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
@@ -28,9 +30,28 @@ type Client struct {
 	// User agent sent when communicating with the LocalBitcoins API.
 	UserAgent string
 
+	// RateLimiter, if set, is waited on before each request is sent, so that
+	// goroutines sharing the Client stay within the LocalBitcoins rate limits.
+	// See GroupLimiter.
+	RateLimiter RateLimiter
+
+	// RetryPolicy, if set, decides which failed requests are retried. Requests
+	// are not retried if it is nil.
+	RetryPolicy *RetryPolicy
+
 	// Services for talking to different parts of the LocalBitcoins API.
-	Accounts *AccountsService
-	Escrows  *EscrowsService
+	Accounts      *AccountsService
+	Ads           *AdsService
+	Contacts      *ContactsService
+	Dashboard     *DashboardService
+	Escrows       *EscrowsService
+	Feedback      *FeedbackService
+	Market        *MarketService
+	Messages      *MessagesService
+	Notifications *NotificationsService
+	Public        *PublicService
+	Reference     *ReferenceService
+	Wallet        *WalletService
 }
 
 // Adds the parameters in opt as URL query parameters to s. opt must be a
@@ -59,7 +80,7 @@ func addOptions(s string, opt interface{}) (string, error) {
 // http.DefaultClient will be used. To use API methods that require
 // authentication (most, if not all, do), provide an http.Client that will
 // perform the authentication for you (such as that provided by the goauth2
-// library).
+// library, or by NewHMACClient).
 func NewClient(httpClient *http.Client) *Client {
 	if httpClient == nil {
 		httpClient = http.DefaultClient
@@ -68,7 +89,17 @@ func NewClient(httpClient *http.Client) *Client {
 
 	c := &Client{client: httpClient, BaseURL: baseURL, UserAgent: userAgent}
 	c.Accounts = &AccountsService{client: c}
+	c.Ads = &AdsService{client: c}
+	c.Contacts = &ContactsService{client: c}
+	c.Dashboard = &DashboardService{client: c}
 	c.Escrows = &EscrowsService{client: c}
+	c.Feedback = &FeedbackService{client: c}
+	c.Market = &MarketService{client: c}
+	c.Messages = &MessagesService{client: c}
+	c.Notifications = &NotificationsService{client: c}
+	c.Public = &PublicService{client: c}
+	c.Reference = &ReferenceService{client: c}
+	c.Wallet = &WalletService{client: c}
 	return c
 }
 
@@ -78,6 +109,34 @@ func NewClient(httpClient *http.Client) *Client {
 // value pointed to by body is JSON encoded and included as the request body.
 func (c *Client) NewRequest(method, urlStr string,
 	body interface{}) (*http.Request, error) {
+	return c.NewEncodedRequest(method, urlStr, body, JSONEncoder)
+}
+
+// Creates an API request with a form-encoded body. A relative URL can be
+// provided in urlStr, which is resolved the same way as in NewRequest. If
+// specified, body must be a struct whose fields may contain "url" tags; its
+// fields are encoded as application/x-www-form-urlencoded data, which is what
+// the write endpoints of the LocalBitcoins API expect.
+func (c *Client) NewFormRequest(method, urlStr string,
+	body interface{}) (*http.Request, error) {
+	return c.NewEncodedRequest(method, urlStr, body, FormEncoder)
+}
+
+// Creates an API request with a multipart/form-data body. A relative URL can
+// be provided in urlStr, which is resolved the same way as in NewRequest. If
+// specified, body must be a struct whose fields may contain "url" tags; its
+// fields are included as form fields alongside the provided uploads.
+func (c *Client) NewMultipartRequest(method, urlStr string, body interface{},
+	uploads ...*Upload) (*http.Request, error) {
+	return c.NewEncodedRequest(method, urlStr, body, MultipartEncoder(uploads...))
+}
+
+// Creates an API request whose body is encoded by enc. A relative URL can be
+// provided in urlStr, which is resolved the same way as in NewRequest. The
+// Content-Type header of the request is set to the media type reported by
+// enc, if any.
+func (c *Client) NewEncodedRequest(method, urlStr string, body interface{},
+	enc BodyEncoder) (*http.Request, error) {
 	rel, err := url.Parse(urlStr)
 	if err != nil {
 		return nil, err
@@ -85,20 +144,20 @@ func (c *Client) NewRequest(method, urlStr string,
 
 	u := c.BaseURL.ResolveReference(rel)
 
-	buf := new(bytes.Buffer)
-	if body != nil {
-		err := json.NewEncoder(buf).Encode(body)
-		if err != nil {
-			return nil, err
-		}
+	r, contentType, err := enc.Encode(body)
+	if err != nil {
+		return nil, err
 	}
 
-	req, err := http.NewRequest(method, u.String(), buf)
+	req, err := http.NewRequest(method, u.String(), r)
 	if err != nil {
 		return nil, err
 	}
 
 	req.Header.Add("User-Agent", c.UserAgent)
+	if contentType != "" {
+		req.Header.Add("Content-Type", contentType)
+	}
 	return req, nil
 }
 
@@ -107,6 +166,11 @@ func (c *Client) NewRequest(method, urlStr string,
 // things like pagination links.
 type Response struct {
 	*http.Response
+
+	// URLs of the next and previous pages of a paginated list, as returned in
+	// the pagination object of the response. Empty if there is no such page.
+	NextPage string
+	PrevPage string
 }
 
 // Creates a new Response for the provided http.Response.
@@ -117,10 +181,67 @@ func newResponse(r *http.Response) *Response {
 
 // Sends an API request and returns the API response. The API response is
 // decoded and stored in the value pointed to by v, or returned as an error if
-// an API error has occurred.
+// an API error has occurred. If v implements the io.Writer interface, the raw
+// response body will be written to v, without attempting to first decode it.
 func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
+	return c.DoContext(req.Context(), req, v)
+}
+
+// DoContext is like Do but sends the request with ctx, which may be used to
+// cancel it or to set a deadline for it. If ctx is done before the response
+// has been received, or while waiting to retry the request, ctx.Err() is
+// returned.
+func (c *Client) DoContext(ctx context.Context, req *http.Request,
+	v interface{}) (*Response, error) {
+	if c.RetryPolicy == nil {
+		return c.do(ctx, req, v)
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.do(ctx, req, v)
+		if !c.RetryPolicy.shouldRetry(c.BaseURL, req, resp, err, attempt) {
+			return resp, err
+		}
+
+		t := time.NewTimer(c.RetryPolicy.backoff(attempt, err))
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			return resp, ctx.Err()
+		}
+
+		// each attempt needs a fresh copy of the request body
+		if req.GetBody != nil {
+			body, berr := req.GetBody()
+			if berr != nil {
+				return resp, err
+			}
+			req = req.WithContext(ctx)
+			req.Body = body
+		}
+	}
+}
+
+// Sends a single attempt of an API request, as described by DoContext.
+func (c *Client) do(ctx context.Context, req *http.Request,
+	v interface{}) (*Response, error) {
+	if c.RateLimiter != nil {
+		if err := c.RateLimiter.Wait(ctx, req, apiPath(c.BaseURL, req)); err != nil {
+			return nil, err
+		}
+	}
+
+	req = req.WithContext(ctx)
 	resp, err := c.client.Do(req)
 	if err != nil {
+		// prefer the context's error, which is more useful to the caller than
+		// the one wrapped by the http.Client
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
 		return nil, err
 	}
 
@@ -135,9 +256,14 @@ func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
 		return response, err
 	}
 
-	if v != nil {
+	if w, ok := v.(io.Writer); ok {
+		_, err = io.Copy(w, resp.Body)
+	} else if v != nil {
 		err = json.NewDecoder(resp.Body).Decode(v)
 	}
+	if data, ok := v.(*ResponseData); ok && err == nil {
+		response.populatePagination(data.Pagination)
+	}
 	return response, err
 }
 
@@ -145,6 +271,11 @@ func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
 type ErrorResponse struct {
 	Response *http.Response
 	Err      Error `json:"error"`
+
+	// Raw body and media type of the response. The body is truncated to
+	// maxErrorBodySize bytes.
+	Body        []byte `json:"-"`
+	ContentType string `json:"-"`
 }
 
 func (r *ErrorResponse) Error() string {
@@ -153,37 +284,128 @@ func (r *ErrorResponse) Error() string {
 		r.Response.StatusCode, r.Err.Message, r.Err.Code)
 }
 
+// Unwrap returns the error object of the response, so that errors.As may be
+// used to access it and errors.Is to match it against the sentinel errors.
+func (r *ErrorResponse) Unwrap() error {
+	return &r.Err
+}
+
+// RateLimitError occurs when the LocalBitcoins API rejects a request because
+// the rate limit has been exceeded.
+type RateLimitError struct {
+	Response *http.Response
+	Err      Error // error object of the response, if it included one
+
+	// How long the API asked to wait before retrying, as parsed from the
+	// Retry-After header. Zero if the API gave no hint.
+	RetryAfter time.Duration
+}
+
+func (r *RateLimitError) Error() string {
+	msg := fmt.Sprintf("%v %v: %d - rate limit exceeded",
+		r.Response.Request.Method, r.Response.Request.URL,
+		r.Response.StatusCode)
+	if r.Err.Message != "" {
+		msg += ": " + r.Err.Message
+	}
+	if r.RetryAfter > 0 {
+		msg += fmt.Sprintf(" (retry after %v)", r.RetryAfter)
+	}
+	return msg
+}
+
+// Unwrap returns the error object of the response, or nil if the response
+// didn't include one.
+func (r *RateLimitError) Unwrap() error {
+	if r.Err.Message == "" && r.Err.Code == 0 && len(r.Err.Errors) == 0 {
+		return nil
+	}
+	return &r.Err
+}
+
+// Error is an error object returned by the LocalBitcoins API.
 type Error struct {
-	Message string `json:"message"`
-	Code    int    `json:"error_code"`
+	Message string    `json:"message"`
+	Code    ErrorCode `json:"error_code"`
+
+	// Validation messages for individual request parameters, keyed by the
+	// name of the parameter.
+	Errors map[string]string `json:"errors,omitempty"`
 }
 
 func (e *Error) Error() string {
 	return fmt.Sprintf(`type %d error with message "%v"`, e.Code, e.Message)
 }
 
+// Is reports whether target is an *Error with the same code as e, which makes
+// errors.Is match API errors against the sentinel errors such as
+// ErrInvalidPIN.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
 // CheckResponse checks the API response for errors, and returns them if
 // present. A response is considered an error if it has a status code outside
 // the 200 range. API error responses are expected to have either no response
-// body, or a JSON response body that maps to ErrorResponse. Any other
-// response body will be silently ignored.
+// body, or a JSON response body that maps to ErrorResponse. Responses with any
+// other body, such as the HTML error pages of proxies, are reported as an
+// *UpstreamError instead, and responses with a status code of 429 as a
+// *RateLimitError.
 func CheckResponse(r *http.Response) error {
 	if c := r.StatusCode; 200 <= c && c <= 299 {
 		return nil
 	}
-	errorResponse := &ErrorResponse{Response: r}
-	data, err := ioutil.ReadAll(r.Body)
-	if err == nil && data != nil {
-		json.Unmarshal(data, errorResponse)
+
+	data, _ := ioutil.ReadAll(io.LimitReader(r.Body, maxErrorDecodeSize))
+	contentType := detectContentType(r.Header.Get("Content-Type"), data)
+
+	// only a snippet of the body is kept on the returned error
+	body := data
+	if len(body) > maxErrorBodySize {
+		body = append([]byte(nil), body[:maxErrorBodySize]...)
+	}
+
+	errorResponse := &ErrorResponse{
+		Response:    r,
+		Body:        body,
+		ContentType: contentType,
+	}
+	// Bodies are decoded regardless of their declared media type, as not all
+	// API responses declare one, but only those holding an error object are
+	// treated as API errors.
+	apiError := new(struct {
+		Err *Error `json:"error"`
+	})
+	isAPIError := len(data) == 0 || contentType != "text/html" &&
+		json.Unmarshal(data, apiError) == nil && apiError.Err != nil
+	if apiError.Err != nil {
+		errorResponse.Err = *apiError.Err
+	}
+
+	if r.StatusCode == http.StatusTooManyRequests {
+		return &RateLimitError{
+			Response:   r,
+			Err:        errorResponse.Err,
+			RetryAfter: parseRetryAfter(r.Header.Get("Retry-After"), time.Now()),
+		}
+	}
+	if !isAPIError {
+		return &UpstreamError{
+			Response:    r,
+			Body:        body,
+			ContentType: contentType,
+		}
 	}
 	return errorResponse
 }
 
-// Represents data as returned by the LocalBitcoins API. Wraps the data and
-// actions objects.
+// Represents data as returned by the LocalBitcoins API. Wraps the data,
+// actions and pagination objects.
 type ResponseData struct {
-	Data    interface{} `json:"data"`
-	Actions interface{} `json:"actions"`
+	Data       interface{} `json:"data"`
+	Actions    interface{} `json:"actions"`
+	Pagination *Pagination `json:"pagination,omitempty"`
 }
 
 // Bool is a helper function that allocates a new bool value to store v and
@@ -210,6 +432,14 @@ func Int(v int) *int {
 	return p
 }
 
+// Int64 is a helper function that allocates a new int64 value to store v and
+// returns a pointer to it.
+func Int64(v int64) *int64 {
+	p := new(int64)
+	*p = v
+	return p
+}
+
 // String is a helper function that allocates a new string value to store v and
 // returns a pointer to it.
 func String(v string) *string {
@@ -2,6 +2,7 @@ package localbitcoins
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -11,6 +12,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 var (
@@ -48,6 +50,20 @@ func testMethod(t *testing.T, r *http.Request, want string) {
 	}
 }
 
+type values map[string]string
+
+func testFormValues(t *testing.T, r *http.Request, values values) {
+	want := url.Values{}
+	for k, v := range values {
+		want.Add(k, v)
+	}
+
+	r.ParseForm()
+	if !reflect.DeepEqual(want, r.Form) {
+		t.Errorf("Request parameters = %v, want %v", r.Form, want)
+	}
+}
+
 func testURLParseError(t *testing.T, err error) {
 	if err == nil {
 		t.Errorf("Expected error to be returned")
@@ -150,6 +166,77 @@ func TestNewRequest_badURL(t *testing.T) {
 	testURLParseError(t, err)
 }
 
+func TestNewFormRequest(t *testing.T) {
+	c := NewClient(nil)
+
+	type TestType struct {
+		A string `url:"a"`
+		B *bool  `url:"b,omitempty"`
+		C int    `url:"c,omitempty"`
+	}
+
+	inURL, outURL := "/foo", defaultBaseURL+"foo"
+	inBody, outBody := &TestType{A: "x y", B: Bool(false)}, "a=x+y&b=false"
+
+	req, _ := c.NewFormRequest("POST", inURL, inBody)
+
+	// test that relative URL was expanded
+	if req.URL.String() != outURL {
+		t.Errorf("NewFormRequest(%v) URL = %v, want %v", inURL, req.URL, outURL)
+	}
+
+	// test that body was form encoded
+	body, _ := ioutil.ReadAll(req.Body)
+	if string(body) != outBody {
+		t.Errorf("NewFormRequest(%v) Body = %v, want %v", inBody, string(body),
+			outBody)
+	}
+
+	contentType := req.Header.Get("Content-Type")
+	if want := "application/x-www-form-urlencoded"; contentType != want {
+		t.Errorf("NewFormRequest() Content-Type = %v, want %v", contentType, want)
+	}
+}
+
+func TestNewFormRequest_badURL(t *testing.T) {
+	c := NewClient(nil)
+	_, err := c.NewFormRequest("POST", ":", nil)
+	testURLParseError(t, err)
+}
+
+func TestNewMultipartRequest(t *testing.T) {
+	c := NewClient(nil)
+
+	type TestType struct {
+		A string `url:"a"`
+	}
+
+	up := &Upload{Field: "f", Name: "n.txt", Reader: strings.NewReader("data")}
+	req, err := c.NewMultipartRequest("POST", "/foo", &TestType{A: "x"}, up)
+	if err != nil {
+		t.Fatalf("NewMultipartRequest returned error: %v", err)
+	}
+
+	if err := req.ParseMultipartForm(1 << 20); err != nil {
+		t.Fatalf("ParseMultipartForm returned error: %v", err)
+	}
+	if got := req.FormValue("a"); got != "x" {
+		t.Errorf("NewMultipartRequest field a = %q, want %q", got, "x")
+	}
+
+	f, h, err := req.FormFile("f")
+	if err != nil {
+		t.Fatalf("FormFile returned error: %v", err)
+	}
+	defer f.Close()
+	if h.Filename != "n.txt" {
+		t.Errorf("NewMultipartRequest file name = %q, want %q", h.Filename, "n.txt")
+	}
+	if b, _ := ioutil.ReadAll(f); string(b) != "data" {
+		t.Errorf("NewMultipartRequest file = %q, want %q", b, "data")
+	}
+}
+
 func TestDo(t *testing.T) {
 	setup()
 	defer teardown()
@@ -175,6 +262,71 @@ func TestDo(t *testing.T) {
 	}
 }
 
+func TestDo_writer(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"A":"a"}`)
+	})
+
+	req, _ := client.NewRequest("GET", "/", nil)
+	buf := new(bytes.Buffer)
+	client.Do(req, buf)
+
+	if want := `{"A":"a"}`; buf.String() != want {
+		t.Errorf("Response body = %v, want %v", buf.String(), want)
+	}
+}
+
+func TestDoContext_canceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	done := make(chan struct{})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		// block until the client gives up on the request
+		<-r.Context().Done()
+		close(done)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	req, _ := client.NewRequest("GET", "/", nil)
+	_, err := client.DoContext(ctx, req, nil)
+	if err != context.Canceled {
+		t.Errorf("DoContext returned error %v, want %v", err, context.Canceled)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Errorf("Request was not aborted on the server")
+	}
+}
+
+func TestDoContext_deadline(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	req, _ := client.NewRequest("GET", "/", nil)
+	_, err := client.DoContext(ctx, req, nil)
+	if err != context.DeadlineExceeded {
+		t.Errorf("DoContext returned error %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
 func TestDo_httpError(t *testing.T) {
 	setup()
 	defer teardown()
@@ -214,11 +366,12 @@ func TestDo_redirectLoop(t *testing.T) {
 }
 
 func TestCheckResponse(t *testing.T) {
+	body := `{
+      "error": {"message": "m", "error_code": 7}}`
 	res := &http.Response{
 		Request:    &http.Request{},
 		StatusCode: http.StatusBadRequest,
-		Body: ioutil.NopCloser(strings.NewReader(`{
-      "error": {"message": "m", "error_code": 7}}`)),
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
 	}
 	err := CheckResponse(res).(*ErrorResponse)
 
@@ -227,8 +380,147 @@ func TestCheckResponse(t *testing.T) {
 	}
 
 	want := &ErrorResponse{
-		Response: res,
-		Err:      Error{Message: "m", Code: 7},
+		Response:    res,
+		Err:         Error{Message: "m", Code: 7},
+		Body:        []byte(body),
+		ContentType: "text/plain",
+	}
+	if !reflect.DeepEqual(err, want) {
+		t.Errorf("Error = %#v, want %#v", err, want)
+	}
+}
+
+func TestCheckResponse_emptyBody(t *testing.T) {
+	res := &http.Response{
+		Request:    &http.Request{},
+		StatusCode: http.StatusNotFound,
+		Body:       ioutil.NopCloser(strings.NewReader("")),
+	}
+	if _, ok := CheckResponse(res).(*ErrorResponse); !ok {
+		t.Errorf("Expected an ErrorResponse.")
+	}
+}
+
+func TestCheckResponse_largeBody(t *testing.T) {
+	body := `{"error": {"message": "m", "error_code": 9, "errors": {"msg": "` +
+		strings.Repeat("x", 2*maxErrorBodySize) + `"}}}`
+	res := &http.Response{
+		Request:    &http.Request{},
+		StatusCode: http.StatusBadRequest,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	}
+	err, ok := CheckResponse(res).(*ErrorResponse)
+	if !ok {
+		t.Fatalf("Expected an ErrorResponse.")
+	}
+
+	if err.Err.Code != 9 {
+		t.Errorf("Err.Code = %v, want 9", err.Err.Code)
+	}
+	if len(err.Body) != maxErrorBodySize {
+		t.Errorf("len(Body) = %v, want %v", len(err.Body), maxErrorBodySize)
+	}
+}
+
+func TestCheckResponse_invalidJSON(t *testing.T) {
+	res := &http.Response{
+		Request:    &http.Request{Method: "GET", URL: &url.URL{Path: "/"}},
+		StatusCode: http.StatusBadGateway,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       ioutil.NopCloser(strings.NewReader(`{"error": `)),
+	}
+	err, ok := CheckResponse(res).(*UpstreamError)
+	if !ok {
+		t.Fatalf("Expected an UpstreamError.")
+	}
+
+	if want := "application/json"; err.ContentType != want {
+		t.Errorf("ContentType = %v, want %v", err.ContentType, want)
+	}
+	if want := `{"error": `; string(err.Body) != want {
+		t.Errorf("Body = %q, want %q", err.Body, want)
+	}
+}
+
+func TestCheckResponse_html(t *testing.T) {
+	res := &http.Response{
+		Request:    &http.Request{Method: "GET", URL: &url.URL{Path: "/"}},
+		StatusCode: http.StatusBadGateway,
+		Body: ioutil.NopCloser(strings.NewReader(
+			"<html><body><h1>502 Bad Gateway</h1></body></html>")),
+	}
+	err, ok := CheckResponse(res).(*UpstreamError)
+	if !ok {
+		t.Fatalf("Expected an UpstreamError.")
+	}
+
+	if want := "text/html"; err.ContentType != want {
+		t.Errorf("ContentType = %v, want %v", err.ContentType, want)
+	}
+	if err.IsChallenge() {
+		t.Errorf("IsChallenge returned true, want false")
+	}
+	if !strings.Contains(err.Error(), "502 Bad Gateway") {
+		t.Errorf("Error() = %q, want it to contain the body", err.Error())
+	}
+}
+
+func TestCheckResponse_cloudflareChallenge(t *testing.T) {
+	res := &http.Response{
+		Request:    &http.Request{Method: "GET", URL: &url.URL{Path: "/"}},
+		StatusCode: http.StatusServiceUnavailable,
+		Header: http.Header{
+			"Content-Type": []string{"text/html; charset=UTF-8"},
+			"Server":       []string{"cloudflare"},
+			"Cf-Ray":       []string{"1234-AMS"},
+		},
+		Body: ioutil.NopCloser(strings.NewReader(
+			"<!DOCTYPE html><title>Just a moment...</title>")),
+	}
+	err, ok := CheckResponse(res).(*UpstreamError)
+	if !ok {
+		t.Fatalf("Expected an UpstreamError.")
+	}
+
+	if !err.IsChallenge() {
+		t.Errorf("IsChallenge returned false, want true")
+	}
+}
+
+func TestCheckResponse_truncatesBody(t *testing.T) {
+	res := &http.Response{
+		Request:    &http.Request{},
+		StatusCode: http.StatusInternalServerError,
+		Body: ioutil.NopCloser(strings.NewReader(
+			strings.Repeat("x", 2*maxErrorBodySize))),
+	}
+	err, ok := CheckResponse(res).(*UpstreamError)
+	if !ok {
+		t.Fatalf("Expected an UpstreamError.")
+	}
+
+	if len(err.Body) != maxErrorBodySize {
+		t.Errorf("len(Body) = %v, want %v", len(err.Body), maxErrorBodySize)
+	}
+}
+
+func TestCheckResponse_rateLimit(t *testing.T) {
+	res := &http.Response{
+		Request:    &http.Request{},
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": []string{"5"}},
+		Body: ioutil.NopCloser(strings.NewReader(`{
+      "error": {"message": "slow down", "error_code": 1}}`)),
+	}
+	err, ok := CheckResponse(res).(*RateLimitError)
+	if !ok {
+		t.Fatalf("Expected a RateLimitError.")
+	}
+
+	want := &RateLimitError{
+		Response:   res,
+		Err:        Error{Message: "slow down", Code: 1},
+		RetryAfter: 5 * time.Second,
 	}
 	if !reflect.DeepEqual(err, want) {
 		t.Errorf("Error = %#v, want %#v", err, want)
@@ -0,0 +1,219 @@
+package localbitcoins
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// MarketService handles communications with the public market data endpoints
+// of the LocalBitcoins API, which follow the format of bitcoincharts.com.
+type MarketService struct {
+	client *Client
+}
+
+// Trade represents a completed trade as returned by the market data endpoints.
+type Trade struct {
+	TID    *int64     `json:"tid,omitempty"`
+	Date   *time.Time `json:"date,omitempty"`
+	Price  *Amount    `json:"price,omitempty"`
+	Amount *Amount    `json:"amount,omitempty"`
+}
+
+func (t Trade) String() string {
+	return Stringify(t)
+}
+
+// OrderBook represents the order book of a currency as returned by the market
+// data endpoints. Bids are sorted by descending price and asks by ascending
+// price.
+type OrderBook struct {
+	Bids []*OrderBookEntry `json:"bids,omitempty"`
+	Asks []*OrderBookEntry `json:"asks,omitempty"`
+}
+
+func (b OrderBook) String() string {
+	return Stringify(b)
+}
+
+// OrderBookEntry is a bid or ask of an order book.
+type OrderBookEntry struct {
+	Price  *Amount `json:"price,omitempty"`
+	Amount *Amount `json:"amount,omitempty"`
+}
+
+func (e OrderBookEntry) String() string {
+	return Stringify(e)
+}
+
+// tradesOptions specifies the optional parameters to the trades endpoint.
+type tradesOptions struct {
+	Since int64 `url:"since,omitempty"`
+}
+
+// Middleman used strictly for unmarshaling trades, whose dates are Unix
+// timestamps.
+type tradeMiddleman struct {
+	TID    *int64  `json:"tid,omitempty"`
+	Date   *int64  `json:"date,omitempty"`
+	Price  *Amount `json:"price,omitempty"`
+	Amount *Amount `json:"amount,omitempty"`
+}
+
+// Order book middleman used strictly for unmarshaling the API response, which
+// represents entries as [price, amount] pairs.
+type orderBookMiddleman struct {
+	Bids [][2]Amount `json:"bids,omitempty"`
+	Asks [][2]Amount `json:"asks,omitempty"`
+}
+
+// Maximum number of times TradesIterator waits out a rate limit error before
+// giving up on a page.
+const tradesRateLimitRetries = 3
+
+// Trades lists the trades in the provided currency, such as "USD", with a
+// trade ID greater than since, in ascending order of trade ID. At most 500
+// trades are listed; use TradesIterator to list them all. If since is zero,
+// the most recent trades are listed.
+func (s *MarketService) Trades(currency string, since int64) ([]*Trade, *Response, error) {
+	return s.TradesContext(context.Background(), currency, since)
+}
+
+// TradesContext is like Trades but uses ctx to control its requests.
+func (s *MarketService) TradesContext(ctx context.Context, currency string, since int64) ([]*Trade, *Response, error) {
+	u, err := tradesURL(currency, since)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.trades(ctx, u)
+}
+
+// TradesIterator returns an Iterator over every trade in the provided currency
+// with a trade ID greater than since, in ascending order of trade ID. It
+// fetches pages until one comes back empty, so it ends once it has caught up
+// with the most recent trade.
+//
+// Requests wait on the client's RateLimiter, if any. In addition, when a page
+// is rejected with a *RateLimitError, the iterator waits for the time the API
+// asks for and tries again, a few times at most. If the client has a
+// RetryPolicy, it is left to decide whether to retry instead.
+func (s *MarketService) TradesIterator(currency string, since int64) *Iterator[*Trade] {
+	u, err := tradesURL(currency, since)
+	if err != nil {
+		return &Iterator[*Trade]{err: err}
+	}
+
+	return NewIterator(u, func(ctx context.Context, u string) ([]*Trade, *Response, error) {
+		trades, resp, err := s.tradesWaiting(ctx, u)
+		if err != nil || len(trades) == 0 {
+			return trades, resp, err
+		}
+
+		last := trades[len(trades)-1]
+		if last.TID != nil {
+			next, err := tradesURL(currency, *last.TID)
+			if err != nil {
+				return nil, resp, err
+			}
+			resp.NextPage = next
+		}
+		return trades, resp, err
+	})
+}
+
+// Fetches the trades at u like trades, waiting out rate limit errors.
+func (s *MarketService) tradesWaiting(ctx context.Context, u string) ([]*Trade, *Response, error) {
+	if s.client.RetryPolicy != nil {
+		// the policy already retries rate limit errors, and waiting on top of
+		// it would multiply the requests
+		return s.trades(ctx, u)
+	}
+
+	for attempt := 0; ; attempt++ {
+		trades, resp, err := s.trades(ctx, u)
+		rlErr, ok := err.(*RateLimitError)
+		if !ok || attempt == tradesRateLimitRetries {
+			return trades, resp, err
+		}
+
+		wait := rlErr.RetryAfter
+		if wait <= 0 {
+			wait = time.Second
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, resp, ctx.Err()
+		}
+	}
+}
+
+// Returns the URL of the trades in currency with a trade ID greater than
+// since.
+func tradesURL(currency string, since int64) (string, error) {
+	u := fmt.Sprintf("bitcoincharts/%v/trades.json", url.PathEscape(currency))
+	return addOptions(u, &tradesOptions{Since: since})
+}
+
+func (s *MarketService) trades(ctx context.Context, u string) ([]*Trade, *Response, error) {
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var middlemen []*tradeMiddleman
+	resp, err := s.client.DoContext(ctx, req, &middlemen)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	trades := make([]*Trade, len(middlemen))
+	for i, m := range middlemen {
+		trades[i] = &Trade{TID: m.TID, Price: m.Price, Amount: m.Amount}
+		if m.Date != nil {
+			date := time.Unix(*m.Date, 0).UTC()
+			trades[i].Date = &date
+		}
+	}
+	return trades, resp, err
+}
+
+// OrderBook fetches the order book of the provided currency, such as "USD".
+func (s *MarketService) OrderBook(currency string) (*OrderBook, *Response, error) {
+	return s.OrderBookContext(context.Background(), currency)
+}
+
+// OrderBookContext is like OrderBook but uses ctx to control its requests.
+func (s *MarketService) OrderBookContext(ctx context.Context, currency string) (*OrderBook, *Response, error) {
+	u := fmt.Sprintf("bitcoincharts/%v/orderbook.json", url.PathEscape(currency))
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	middleman := new(orderBookMiddleman)
+	resp, err := s.client.DoContext(ctx, req, middleman)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	book := &OrderBook{
+		Bids: orderBookEntries(middleman.Bids),
+		Asks: orderBookEntries(middleman.Asks),
+	}
+	return book, resp, err
+}
+
+// Returns the order book entries of [price, amount] pairs.
+func orderBookEntries(pairs [][2]Amount) []*OrderBookEntry {
+	entries := make([]*OrderBookEntry, len(pairs))
+	for i, p := range pairs {
+		price, amount := p[0], p[1]
+		entries[i] = &OrderBookEntry{Price: &price, Amount: &amount}
+	}
+	return entries
+}
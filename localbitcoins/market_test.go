@@ -0,0 +1,196 @@
+package localbitcoins
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMarketService_Trades(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/bitcoincharts/USD/trades.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"since": "100"})
+		fmt.Fprint(w, `[
+      {"tid":101,"date":1400000000,"price":"450.12","amount":"0.12345678"},
+      {"tid":102,"date":1400000060,"price":"451.00","amount":"1.00000000"}
+    ]`)
+	})
+
+	trades, _, err := client.Market.Trades("USD", 100)
+	if err != nil {
+		t.Errorf("Market.Trades returned error: %v", err)
+	}
+
+	d1, d2 := time.Unix(1400000000, 0).UTC(), time.Unix(1400000060, 0).UTC()
+	p1, a1 := MustParseAmount("450.12"), MustParseAmount("0.12345678")
+	p2, a2 := MustParseAmount("451.00"), MustParseAmount("1.00000000")
+	want := []*Trade{
+		{TID: Int64(101), Date: &d1, Price: &p1, Amount: &a1},
+		{TID: Int64(102), Date: &d2, Price: &p2, Amount: &a2},
+	}
+	if !reflect.DeepEqual(trades, want) {
+		t.Errorf("Market.Trades returned %+v, want %+v", trades, want)
+	}
+}
+
+func TestMarketService_Trades_recent(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/bitcoincharts/EUR/trades.json", func(w http.ResponseWriter, r *http.Request) {
+		testFormValues(t, r, values{})
+		fmt.Fprint(w, `[]`)
+	})
+
+	if _, _, err := client.Market.Trades("EUR", 0); err != nil {
+		t.Errorf("Market.Trades returned error: %v", err)
+	}
+}
+
+func TestMarketService_TradesIterator(t *testing.T) {
+	setup()
+	defer teardown()
+
+	pages := map[string]string{
+		"5": `[{"tid":6},{"tid":7}]`,
+		"7": `[{"tid":8}]`,
+		"8": `[]`,
+	}
+	var requests []string
+	mux.HandleFunc("/bitcoincharts/USD/trades.json", func(w http.ResponseWriter, r *http.Request) {
+		since := r.FormValue("since")
+		requests = append(requests, since)
+		fmt.Fprint(w, pages[since])
+	})
+
+	trades, err := client.Market.TradesIterator("USD", 5).All()
+	if err != nil {
+		t.Errorf("Market.TradesIterator returned error: %v", err)
+	}
+
+	var tids []int64
+	for _, tr := range trades {
+		tids = append(tids, *tr.TID)
+	}
+	if want := []int64{6, 7, 8}; !reflect.DeepEqual(tids, want) {
+		t.Errorf("Market.TradesIterator returned trades %v, want %v", tids, want)
+	}
+	if want := []string{"5", "7", "8"}; !reflect.DeepEqual(requests, want) {
+		t.Errorf("Market.TradesIterator requested since %v, want %v", requests, want)
+	}
+}
+
+func TestMarketService_TradesIterator_rateLimited(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests int
+	mux.HandleFunc("/bitcoincharts/USD/trades.json", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch {
+		case requests == 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, `{"error":{"message":"slow down","error_code":1}}`)
+		case r.FormValue("since") == "1":
+			fmt.Fprint(w, `[{"tid":2}]`)
+		default:
+			fmt.Fprint(w, `[]`)
+		}
+	})
+
+	start := time.Now()
+	trades, err := client.Market.TradesIterator("USD", 1).All()
+	if err != nil {
+		t.Errorf("Market.TradesIterator returned error: %v", err)
+	}
+	if len(trades) != 1 {
+		t.Errorf("Market.TradesIterator returned %v trades, want 1", len(trades))
+	}
+	if requests != 3 {
+		t.Errorf("Market.TradesIterator made %v requests, want 3", requests)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("Market.TradesIterator waited %v after the rate limit error, want at least 1s", elapsed)
+	}
+}
+
+func TestMarketService_TradesIterator_retryPolicy(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests int
+	mux.HandleFunc("/bitcoincharts/USD/trades.json", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	client.RetryPolicy = &RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond}
+	_, err := client.Market.TradesIterator("USD", 1).All()
+	if _, ok := err.(*RateLimitError); !ok {
+		t.Errorf("Expected a RateLimitError; got %#v.", err)
+	}
+	if requests != 2 {
+		t.Errorf("Market.TradesIterator made %v requests, want 2", requests)
+	}
+}
+
+func TestMarketService_escapesCurrency(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var paths []string
+	mux.HandleFunc("/bitcoincharts/", func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.EscapedPath())
+		fmt.Fprint(w, `{}`)
+	})
+
+	client.Market.Trades("a/b", 0)
+	client.Market.OrderBook("a/b")
+
+	want := []string{
+		"/bitcoincharts/a%2Fb/trades.json",
+		"/bitcoincharts/a%2Fb/orderbook.json",
+	}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("Request paths: %v, want %v", paths, want)
+	}
+}
+
+func TestMarketService_OrderBook(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/bitcoincharts/USD/orderbook.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{
+      "bids":[["450.10","0.50000000"],["449.00","2.10000000"]],
+      "asks":[["455.00","0.01000000"]]
+    }`)
+	})
+
+	book, _, err := client.Market.OrderBook("USD")
+	if err != nil {
+		t.Errorf("Market.OrderBook returned error: %v", err)
+	}
+
+	entry := func(price, amount string) *OrderBookEntry {
+		p, a := MustParseAmount(price), MustParseAmount(amount)
+		return &OrderBookEntry{Price: &p, Amount: &a}
+	}
+	want := &OrderBook{
+		Bids: []*OrderBookEntry{
+			entry("450.10", "0.50000000"),
+			entry("449.00", "2.10000000"),
+		},
+		Asks: []*OrderBookEntry{entry("455.00", "0.01000000")},
+	}
+	if !reflect.DeepEqual(book, want) {
+		t.Errorf("Market.OrderBook returned %+v, want %+v", book, want)
+	}
+}
@@ -0,0 +1,175 @@
+package localbitcoins
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// MessagesService handles all communications related to the messages of
+// contacts (trade chat) with the LocalBitcoins API.
+type MessagesService struct {
+	client *Client
+}
+
+// Message represents a message sent in the chat of a contact.
+type Message struct {
+	Message   *string    `json:"msg,omitempty"`
+	Sender    *Profile   `json:"sender,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	IsAdmin   *bool      `json:"is_admin,omitempty"`
+
+	// Attachment is the file attached to the message, if any.
+	Attachment *Attachment `json:"-"`
+}
+
+func (m Message) String() string {
+	return Stringify(m)
+}
+
+// Attachment represents a file attached to a message.
+type Attachment struct {
+	Name *string `json:"attachment_name,omitempty"`
+	Type *string `json:"attachment_type,omitempty"`
+	URL  *string `json:"attachment_url,omitempty"`
+}
+
+func (a Attachment) String() string {
+	return Stringify(a)
+}
+
+// Message list middleman used strictly for unmarshaling the API response.
+type messageListMiddleman struct {
+	Messages []*messageMiddleman `json:"message_list,omitempty"`
+}
+
+// Middleman used strictly for unmarshaling individual messages, whose
+// attachment fields are included alongside the fields of the message.
+type messageMiddleman struct {
+	*Message
+	*Attachment
+}
+
+// Middleman used strictly for unmarshaling the result of posting a message.
+type messagePostMiddleman struct {
+	Message *string `json:"message,omitempty"`
+}
+
+// List lists the messages of the contact with the provided ID.
+func (s *MessagesService) List(contactID int) ([]*Message, *Response, error) {
+	return s.ListContext(context.Background(), contactID)
+}
+
+// ListContext is like List but uses ctx to control its requests.
+func (s *MessagesService) ListContext(ctx context.Context, contactID int) ([]*Message, *Response, error) {
+	u := fmt.Sprintf("api/contact_messages/%v/", contactID)
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	middleman := new(messageListMiddleman)
+	respMiddleman := &ResponseData{Data: middleman}
+	resp, err := s.client.DoContext(ctx, req, respMiddleman)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	messages := make([]*Message, len(middleman.Messages))
+	for i, m := range middleman.Messages {
+		messages[i] = m.Message
+		if messages[i] == nil {
+			messages[i] = new(Message)
+		}
+		if a := m.Attachment; a != nil && a.URL != nil {
+			messages[i].Attachment = a
+		}
+	}
+
+	return messages, resp, err
+}
+
+// Post posts a message to the chat of the contact with the provided ID. The
+// message returned by the API is passed back to the caller.
+func (s *MessagesService) Post(contactID int, msg string) (string, *Response, error) {
+	return s.PostContext(context.Background(), contactID, msg)
+}
+
+// PostContext is like Post but uses ctx to control its requests.
+func (s *MessagesService) PostContext(ctx context.Context, contactID int, msg string) (string, *Response, error) {
+	u := fmt.Sprintf("api/contact_message_post/%v/", contactID)
+	req, err := s.client.NewFormRequest("POST", u, messagePostOptions{msg})
+	if err != nil {
+		return "", nil, err
+	}
+
+	return s.post(ctx, req)
+}
+
+// PostAttachment posts a message to the chat of the contact with the provided
+// ID, attaching the contents of r as a file with the provided name. The
+// message returned by the API is passed back to the caller.
+func (s *MessagesService) PostAttachment(contactID int, msg, name string,
+	r io.Reader) (string, *Response, error) {
+	return s.PostAttachmentContext(context.Background(), contactID, msg, name, r)
+}
+
+// PostAttachmentContext is like PostAttachment but uses ctx to control its
+// requests.
+func (s *MessagesService) PostAttachmentContext(ctx context.Context, contactID int, msg, name string,
+	r io.Reader) (string, *Response, error) {
+	u := fmt.Sprintf("api/contact_message_post/%v/", contactID)
+	doc := &Upload{Field: "document", Name: name, Reader: r}
+	req, err := s.client.NewMultipartRequest("POST", u,
+		messagePostOptions{msg}, doc)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return s.post(ctx, req)
+}
+
+// Parameters of a message post.
+type messagePostOptions struct {
+	Message string `url:"msg"`
+}
+
+func (s *MessagesService) post(ctx context.Context, req *http.Request) (string, *Response, error) {
+	result := new(messagePostMiddleman)
+	respMiddleman := &ResponseData{Data: result}
+	resp, err := s.client.DoContext(ctx, req, respMiddleman)
+	if err != nil {
+		return "", resp, err
+	}
+
+	var msg string
+	if result.Message != nil {
+		msg = *result.Message
+	}
+	return msg, resp, err
+}
+
+// DownloadAttachment downloads the provided attachment, streaming its contents
+// to w. If the attachment has no URL, a *MissingActionError is returned.
+func (s *MessagesService) DownloadAttachment(a *Attachment,
+	w io.Writer) (*Response, error) {
+	return s.DownloadAttachmentContext(context.Background(), a, w)
+}
+
+// DownloadAttachmentContext is like DownloadAttachment but uses ctx to control
+// its requests.
+func (s *MessagesService) DownloadAttachmentContext(ctx context.Context, a *Attachment,
+	w io.Writer) (*Response, error) {
+	if a == nil || a.URL == nil || *a.URL == "" {
+		return nil, &MissingActionError{Action: "attachment download"}
+	}
+
+	req, err := s.client.NewRequest("GET", *a.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.DoContext(ctx, req, w)
+}
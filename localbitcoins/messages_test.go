@@ -0,0 +1,137 @@
+package localbitcoins
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMessagesService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/contact_messages/1/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{
+      "data":{
+        "message_list":[
+          {"msg":"hello","sender":{"username":"foo"},"is_admin":false},
+          {
+            "msg":"receipt",
+            "attachment_name":"receipt.jpg",
+            "attachment_type":"image/jpeg",
+            "attachment_url":"/api/contact_message_attachment/1/2/"
+          }
+        ],
+        "message_count":2
+      }
+    }`)
+	})
+
+	messages, _, err := client.Messages.List(1)
+	if err != nil {
+		t.Errorf("Messages.List returned error: %v", err)
+	}
+
+	want := []*Message{
+		&Message{
+			Message: String("hello"),
+			Sender:  &Profile{Username: String("foo")},
+			IsAdmin: Bool(false),
+		},
+		&Message{
+			Message: String("receipt"),
+			Attachment: &Attachment{
+				Name: String("receipt.jpg"),
+				Type: String("image/jpeg"),
+				URL:  String("/api/contact_message_attachment/1/2/"),
+			},
+		},
+	}
+	if !reflect.DeepEqual(messages, want) {
+		t.Errorf("Messages.List returned %+v, want %+v", messages, want)
+	}
+}
+
+func TestMessagesService_Post(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/contact_message_post/1/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testFormValues(t, r, values{"msg": "hello"})
+		fmt.Fprint(w, `{"data":{"message":"Message posted."}}`)
+	})
+
+	msg, _, err := client.Messages.Post(1, "hello")
+	if err != nil {
+		t.Errorf("Messages.Post returned error: %v", err)
+	}
+	if want := "Message posted."; msg != want {
+		t.Errorf("Messages.Post returned %q, want %q", msg, want)
+	}
+}
+
+func TestMessagesService_PostAttachment(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/contact_message_post/1/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("ParseMultipartForm returned error: %v", err)
+		}
+		if got, want := r.FormValue("msg"), "receipt"; got != want {
+			t.Errorf("msg = %q, want %q", got, want)
+		}
+
+		f, h, err := r.FormFile("document")
+		if err != nil {
+			t.Fatalf("FormFile returned error: %v", err)
+		}
+		defer f.Close()
+		if want := "receipt.txt"; h.Filename != want {
+			t.Errorf("Filename = %q, want %q", h.Filename, want)
+		}
+		if b, _ := ioutil.ReadAll(f); string(b) != "paid" {
+			t.Errorf("document = %q, want %q", b, "paid")
+		}
+
+		fmt.Fprint(w, `{"data":{"message":"Message posted."}}`)
+	})
+
+	_, _, err := client.Messages.PostAttachment(1, "receipt", "receipt.txt",
+		strings.NewReader("paid"))
+	if err != nil {
+		t.Errorf("Messages.PostAttachment returned error: %v", err)
+	}
+}
+
+func TestMessagesService_DownloadAttachment(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/contact_message_attachment/1/2/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, "file contents")
+	})
+
+	buf := new(bytes.Buffer)
+	a := &Attachment{URL: String("/api/contact_message_attachment/1/2/")}
+	_, err := client.Messages.DownloadAttachment(a, buf)
+	if err != nil {
+		t.Errorf("Messages.DownloadAttachment returned error: %v", err)
+	}
+	if want := "file contents"; buf.String() != want {
+		t.Errorf("Messages.DownloadAttachment wrote %q, want %q", buf.String(), want)
+	}
+
+	_, err = client.Messages.DownloadAttachment(&Attachment{}, buf)
+	if _, ok := err.(*MissingActionError); !ok {
+		t.Errorf("Expected a MissingActionError; got %#v.", err)
+	}
+}
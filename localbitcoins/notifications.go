@@ -0,0 +1,167 @@
+package localbitcoins
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// NotificationsService handles all notification-related communications with
+// the LocalBitcoins API.
+type NotificationsService struct {
+	client *Client
+}
+
+// Notification represents a notification of the authenticated account, as
+// returned by the LocalBitcoins API.
+type Notification struct {
+	ID        *string    `json:"id,omitempty"`
+	URL       *string    `json:"url,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	Read      *bool      `json:"read,omitempty"`
+	Message   *string    `json:"msg,omitempty"`
+
+	// ID of the contact or advertisement the notification is about, if any.
+	ContactID *int `json:"contact_id,omitempty"`
+	AdID      *int `json:"advertisement_id,omitempty"`
+}
+
+func (n Notification) String() string {
+	return Stringify(n)
+}
+
+// Middleman used strictly for unmarshaling the result of marking a
+// notification as read.
+type notificationResultMiddleman struct {
+	Message *string `json:"message,omitempty"`
+}
+
+// List lists the recent notifications of the authenticated account.
+func (s *NotificationsService) List() ([]*Notification, *Response, error) {
+	return s.ListContext(context.Background())
+}
+
+// ListContext is like List but uses ctx to control its requests.
+func (s *NotificationsService) ListContext(ctx context.Context) ([]*Notification, *Response, error) {
+	req, err := s.client.NewRequest("GET", "api/notifications/", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var notifications []*Notification
+	respMiddleman := &ResponseData{Data: &notifications}
+	resp, err := s.client.DoContext(ctx, req, respMiddleman)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return notifications, resp, err
+}
+
+// MarkAsRead marks the notification with the provided ID as read. The message
+// returned by the API is passed back to the caller.
+func (s *NotificationsService) MarkAsRead(id string) (string, *Response, error) {
+	return s.MarkAsReadContext(context.Background(), id)
+}
+
+// MarkAsReadContext is like MarkAsRead but uses ctx to control its requests.
+func (s *NotificationsService) MarkAsReadContext(ctx context.Context, id string) (string, *Response, error) {
+	u := fmt.Sprintf("api/notifications/mark_as_read/%v/", id)
+	req, err := s.client.NewFormRequest("POST", u, nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	result := new(notificationResultMiddleman)
+	respMiddleman := &ResponseData{Data: result}
+	resp, err := s.client.DoContext(ctx, req, respMiddleman)
+	if err != nil {
+		return "", resp, err
+	}
+
+	var msg string
+	if result.Message != nil {
+		msg = *result.Message
+	}
+	return msg, resp, err
+}
+
+// Interval at which notifications are polled if none is provided.
+const defaultPollInterval = time.Minute
+
+// Watch polls the notifications of the authenticated account every interval
+// until ctx is done, and sends each unread notification it has not sent before
+// on the returned notification channel. Notifications are deduplicated by ID.
+//
+// Errors of individual polls are sent on the returned error channel, which is
+// buffered; errors are dropped rather than stall polling if it is not read.
+// Both channels are closed once ctx is done. If interval is not positive,
+// notifications are polled every minute.
+func (s *NotificationsService) Watch(ctx context.Context, interval time.Duration) (<-chan *Notification, <-chan error) {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	notifications := make(chan *Notification)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(notifications)
+		defer close(errs)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		seen := make(map[string]bool)
+		for {
+			list, _, err := s.ListContext(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				select {
+				case errs <- err:
+				default:
+				}
+			} else {
+				var fresh []*Notification
+				fresh, seen = unseenNotifications(list, seen)
+				for _, n := range fresh {
+					select {
+					case notifications <- n:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return notifications, errs
+}
+
+// Returns the unread notifications of list whose IDs are not in seen, along
+// with the IDs of list, which replace seen for the next poll. Since the API
+// only lists recent notifications, IDs that dropped out of the list never
+// return and need not be remembered.
+func unseenNotifications(list []*Notification, seen map[string]bool) ([]*Notification, map[string]bool) {
+	var fresh []*Notification
+	ids := make(map[string]bool, len(list))
+	for _, n := range list {
+		if n.ID == nil {
+			continue
+		}
+		ids[*n.ID] = true
+		if seen[*n.ID] || (n.Read != nil && *n.Read) {
+			continue
+		}
+		fresh = append(fresh, n)
+	}
+	return fresh, ids
+}
@@ -0,0 +1,156 @@
+package localbitcoins
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNotificationsService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/notifications/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{
+      "data":[
+        {"id":"a1","url":"/request/online_sell_buyer/7/","read":false,"msg":"New offer","contact_id":7},
+        {"id":"b2","read":true,"msg":"Ad hidden","advertisement_id":3}
+      ]
+    }`)
+	})
+
+	notifications, _, err := client.Notifications.List()
+	if err != nil {
+		t.Errorf("Notifications.List returned error: %v", err)
+	}
+
+	want := []*Notification{
+		{
+			ID:        String("a1"),
+			URL:       String("/request/online_sell_buyer/7/"),
+			Read:      Bool(false),
+			Message:   String("New offer"),
+			ContactID: Int(7),
+		},
+		{
+			ID:      String("b2"),
+			Read:    Bool(true),
+			Message: String("Ad hidden"),
+			AdID:    Int(3),
+		},
+	}
+	if !reflect.DeepEqual(notifications, want) {
+		t.Errorf("Notifications.List returned %+v, want %+v", notifications, want)
+	}
+}
+
+func TestNotificationsService_MarkAsRead(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/notifications/mark_as_read/a1/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, `{"data":{"message":"Notification marked as read."}}`)
+	})
+
+	msg, _, err := client.Notifications.MarkAsRead("a1")
+	if err != nil {
+		t.Errorf("Notifications.MarkAsRead returned error: %v", err)
+	}
+
+	if want := "Notification marked as read."; msg != want {
+		t.Errorf("Notifications.MarkAsRead returned %q, want %q", msg, want)
+	}
+}
+
+func TestNotificationsService_Watch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	polls := []string{
+		`[{"id":"a","read":false},{"id":"b","read":true}]`,
+		`[{"id":"a","read":false},{"id":"b","read":true}]`,
+		`[{"id":"c","read":false},{"id":"a","read":false}]`,
+	}
+	var mu sync.Mutex
+	var poll int
+	mux.HandleFunc("/api/notifications/", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case poll == 1:
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":{"message":"m","error_code":1}}`)
+		case poll < len(polls):
+			fmt.Fprintf(w, `{"data":%v}`, polls[poll])
+		default:
+			fmt.Fprintf(w, `{"data":%v}`, polls[len(polls)-1])
+		}
+		poll++
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	notifications, errs := client.Notifications.Watch(ctx, time.Millisecond)
+
+	var ids []string
+	for len(ids) < 2 {
+		select {
+		case n := <-notifications:
+			ids = append(ids, *n.ID)
+		case <-time.After(time.Second):
+			t.Fatalf("Notifications.Watch timed out, got %v", ids)
+		}
+	}
+	if want := []string{"a", "c"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("Notifications.Watch sent %v, want %v", ids, want)
+	}
+
+	select {
+	case err := <-errs:
+		if err == nil {
+			t.Errorf("Notifications.Watch sent a nil error")
+		}
+	default:
+		t.Errorf("Notifications.Watch did not report the failed poll")
+	}
+
+	cancel()
+	for range notifications {
+	}
+	if _, ok := <-errs; ok {
+		t.Errorf("Notifications.Watch did not close the error channel")
+	}
+}
+
+func TestNotificationsService_Watch_zeroInterval(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/notifications/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":[{"id":"a","read":false}]}`)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	notifications, _ := client.Notifications.Watch(ctx, 0)
+	select {
+	case n := <-notifications:
+		if *n.ID != "a" {
+			t.Errorf("Notifications.Watch sent %v, want a", *n.ID)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Notifications.Watch timed out")
+	}
+
+	cancel()
+	for range notifications {
+	}
+}
@@ -0,0 +1,137 @@
+package localbitcoins
+
+import (
+	"context"
+	"fmt"
+)
+
+// Pagination represents the pagination object returned by the LocalBitcoins
+// API alongside paginated lists. It holds the URLs of the neighboring pages.
+type Pagination struct {
+	Next *string `json:"next,omitempty"`
+	Prev *string `json:"prev,omitempty"`
+}
+
+func (p Pagination) String() string {
+	return Stringify(p)
+}
+
+// Sets the page URLs of r from p.
+func (r *Response) populatePagination(p *Pagination) {
+	if p == nil {
+		return
+	}
+	if p.Next != nil {
+		r.NextPage = *p.Next
+	}
+	if p.Prev != nil {
+		r.PrevPage = *p.Prev
+	}
+}
+
+// PageFunc fetches the page of a list endpoint at the provided URL using ctx,
+// returning the items on that page.
+type PageFunc[T any] func(ctx context.Context, urlStr string) ([]T, *Response, error)
+
+// Iterator walks every item of a paginated list, fetching pages as they are
+// needed by following Response.NextPage. Use it like this:
+//
+//	it := client.Dashboard.ClosedIterator()
+//	for it.Next() {
+//		contact := it.Value()
+//		// ...
+//	}
+//	if err := it.Err(); err != nil {
+//		// ...
+//	}
+type Iterator[T any] struct {
+	fetch PageFunc[T]
+	next  string
+	seen  map[string]bool
+
+	items []T
+	i     int
+	resp  *Response
+	err   error
+}
+
+// NewIterator returns an Iterator over the list whose first page is at urlStr,
+// using fetch to fetch each page. The list endpoints of the services provide
+// their own iterators; NewIterator is useful for endpoints they don't cover.
+func NewIterator[T any](urlStr string, fetch PageFunc[T]) *Iterator[T] {
+	return &Iterator[T]{fetch: fetch, next: urlStr}
+}
+
+// Next advances the iterator to the next item, fetching the next page if
+// needed. It returns false when there are no more items or an error occurred.
+func (it *Iterator[T]) Next() bool {
+	return it.NextContext(context.Background())
+}
+
+// NextContext is like Next but uses ctx to control the request fetching the
+// next page, if any.
+//
+// If a page links to a page that was already fetched, the iteration stops
+// with an error once the items of that page have been returned.
+func (it *Iterator[T]) NextContext(ctx context.Context) bool {
+	for it.i >= len(it.items) {
+		if it.err != nil || it.next == "" {
+			return false
+		}
+
+		if it.seen == nil {
+			it.seen = make(map[string]bool)
+		}
+		it.seen[it.next] = true
+
+		it.items, it.resp, it.err = it.fetch(ctx, it.next)
+		it.i = 0
+		it.next = ""
+		if it.err != nil {
+			it.items = nil
+			return false
+		}
+		if it.resp != nil && it.resp.NextPage != "" {
+			if it.seen[it.resp.NextPage] {
+				// Stop after the items of this page, as following the link
+				// would never end.
+				it.err = fmt.Errorf("localbitcoins: pagination loop: page %v was already fetched", it.resp.NextPage)
+			} else {
+				it.next = it.resp.NextPage
+			}
+		}
+	}
+
+	it.i++
+	return true
+}
+
+// Value returns the current item. It must only be called after Next returned
+// true.
+func (it *Iterator[T]) Value() T {
+	return it.items[it.i-1]
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Response returns the response of the last page fetched.
+func (it *Iterator[T]) Response() *Response {
+	return it.resp
+}
+
+// All fetches every remaining item and returns them.
+func (it *Iterator[T]) All() ([]T, error) {
+	return it.AllContext(context.Background())
+}
+
+// AllContext is like All but uses ctx to control its requests.
+func (it *Iterator[T]) AllContext(ctx context.Context) ([]T, error) {
+	var all []T
+	for it.NextContext(ctx) {
+		all = append(all, it.Value())
+	}
+	return all, it.Err()
+}
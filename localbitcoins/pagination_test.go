@@ -0,0 +1,153 @@
+package localbitcoins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestDo_pagination(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{},"pagination":{"next":"/?page=3","prev":"/?page=1"}}`)
+	})
+
+	req, _ := client.NewRequest("GET", "/", nil)
+	resp, err := client.Do(req, &ResponseData{})
+	if err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+
+	if want := "/?page=3"; resp.NextPage != want {
+		t.Errorf("NextPage = %v, want %v", resp.NextPage, want)
+	}
+	if want := "/?page=1"; resp.PrevPage != want {
+		t.Errorf("PrevPage = %v, want %v", resp.PrevPage, want)
+	}
+}
+
+func TestIterator(t *testing.T) {
+	pages := map[string]struct {
+		items []int
+		next  string
+	}{
+		"a": {[]int{1, 2}, "b"},
+		"b": {nil, "c"},
+		"c": {[]int{3}, ""},
+	}
+
+	var fetched []string
+	it := NewIterator("a", func(ctx context.Context, u string) ([]int, *Response, error) {
+		fetched = append(fetched, u)
+		p := pages[u]
+		return p.items, &Response{NextPage: p.next}, nil
+	})
+
+	items, err := it.All()
+	if err != nil {
+		t.Errorf("All returned error: %v", err)
+	}
+	if want := []int{1, 2, 3}; !reflect.DeepEqual(items, want) {
+		t.Errorf("All returned %v, want %v", items, want)
+	}
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(fetched, want) {
+		t.Errorf("Fetched pages %v, want %v", fetched, want)
+	}
+	if it.Next() {
+		t.Errorf("Next returned true after the last page")
+	}
+}
+
+func TestIterator_error(t *testing.T) {
+	e := errors.New("boom")
+	it := NewIterator("a", func(ctx context.Context, u string) ([]int, *Response, error) {
+		if u == "a" {
+			return []int{1}, &Response{NextPage: "b"}, nil
+		}
+		return nil, nil, e
+	})
+
+	items, err := it.All()
+	if err != e {
+		t.Errorf("All returned error %v, want %v", err, e)
+	}
+	if want := []int{1}; !reflect.DeepEqual(items, want) {
+		t.Errorf("All returned %v, want %v", items, want)
+	}
+}
+
+func TestIterator_loop(t *testing.T) {
+	fetches := 0
+	it := NewIterator("a", func(ctx context.Context, u string) ([]int, *Response, error) {
+		fetches++
+		if fetches > 3 {
+			t.Fatalf("Fetched page %v again", u)
+		}
+		if u == "a" {
+			return []int{1}, &Response{NextPage: "b"}, nil
+		}
+		return []int{2}, &Response{NextPage: "a"}, nil
+	})
+
+	items, err := it.All()
+	if err == nil {
+		t.Errorf("Expected an error for the pagination loop")
+	}
+	if want := []int{1, 2}; !reflect.DeepEqual(items, want) {
+		t.Errorf("All returned %v, want %v", items, want)
+	}
+	if fetches != 2 {
+		t.Errorf("Fetched %d pages, want 2", fetches)
+	}
+}
+
+func TestDashboardService_ClosedIterator(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/dashboard/closed/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `{"data":{"contact_list":[{"data":{"contact_id":2}}]}}`)
+			return
+		}
+		fmt.Fprint(w, `{
+      "data":{"contact_list":[{"data":{"contact_id":1}}]},
+      "pagination":{"next":"/api/dashboard/closed/?page=2"}
+    }`)
+	})
+
+	contacts, err := client.Dashboard.ClosedIterator().All()
+	if err != nil {
+		t.Errorf("Dashboard.ClosedIterator returned error: %v", err)
+	}
+
+	want := []*Contact{&Contact{ID: Int(1)}, &Contact{ID: Int(2)}}
+	if !reflect.DeepEqual(contacts, want) {
+		t.Errorf("Dashboard.ClosedIterator returned %+v, want %+v", contacts, want)
+	}
+}
+
+func TestAdsService_ListIterator(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/ads/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data":{"ad_list":[{"data":{"ad_id":1}}]}}`)
+	})
+
+	it := client.Ads.ListIterator(nil)
+	ads, err := it.All()
+	if err != nil {
+		t.Errorf("Ads.ListIterator returned error: %v", err)
+	}
+	if want := []*Ad{&Ad{ID: Int(1)}}; !reflect.DeepEqual(ads, want) {
+		t.Errorf("Ads.ListIterator returned %+v, want %+v", ads, want)
+	}
+}
@@ -0,0 +1,131 @@
+package localbitcoins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// PublicService handles communications with the public, unauthenticated
+// advertisement listings of the LocalBitcoins API. These are the same
+// listings shown on the LocalBitcoins website.
+type PublicService struct {
+	client *Client
+}
+
+// PublicAdsOptions specifies the optional filters of the online advertisement
+// listings of PublicService. At most one of CountryCode and Currency may be
+// provided.
+type PublicAdsOptions struct {
+	// Two letter country code, such as "US", and the name of that country as
+	// used in LocalBitcoins URLs, such as "united-states". Both must be
+	// provided to filter by country.
+	CountryCode string
+	CountryName string
+
+	// Three letter currency code, such as "USD".
+	Currency string
+
+	// Payment method code, such as "national-bank-transfer".
+	PaymentMethod string
+}
+
+// BuyOnline lists the advertisements of users selling bitcoin online, following
+// pagination until every advertisement has been fetched. The returned Response
+// is the one of the last page fetched.
+func (s *PublicService) BuyOnline(opt *PublicAdsOptions) ([]*Ad, *Response, error) {
+	return s.BuyOnlineContext(context.Background(), opt)
+}
+
+// BuyOnlineContext is like BuyOnline but uses ctx to control its requests.
+func (s *PublicService) BuyOnlineContext(ctx context.Context, opt *PublicAdsOptions) ([]*Ad, *Response, error) {
+	u, err := onlineAdsURL("buy-bitcoins-online", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.listAds(ctx, u)
+}
+
+// SellOnline lists the advertisements of users buying bitcoin online, following
+// pagination until every advertisement has been fetched. The returned Response
+// is the one of the last page fetched.
+func (s *PublicService) SellOnline(opt *PublicAdsOptions) ([]*Ad, *Response, error) {
+	return s.SellOnlineContext(context.Background(), opt)
+}
+
+// SellOnlineContext is like SellOnline but uses ctx to control its requests.
+func (s *PublicService) SellOnlineContext(ctx context.Context, opt *PublicAdsOptions) ([]*Ad, *Response, error) {
+	u, err := onlineAdsURL("sell-bitcoins-online", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.listAds(ctx, u)
+}
+
+// BuyLocal lists the advertisements of users selling bitcoin for cash near the
+// place with the provided location ID and slug, as returned by the places API.
+// Pagination is followed until every advertisement has been fetched.
+func (s *PublicService) BuyLocal(locationID int, slug string) ([]*Ad, *Response, error) {
+	return s.BuyLocalContext(context.Background(), locationID, slug)
+}
+
+// BuyLocalContext is like BuyLocal but uses ctx to control its requests.
+func (s *PublicService) BuyLocalContext(ctx context.Context, locationID int, slug string) ([]*Ad, *Response, error) {
+	return s.listAds(ctx, localAdsURL("buy-bitcoins-with-cash", locationID, slug))
+}
+
+// SellLocal lists the advertisements of users buying bitcoin for cash near the
+// place with the provided location ID and slug, as returned by the places API.
+// Pagination is followed until every advertisement has been fetched.
+func (s *PublicService) SellLocal(locationID int, slug string) ([]*Ad, *Response, error) {
+	return s.SellLocalContext(context.Background(), locationID, slug)
+}
+
+// SellLocalContext is like SellLocal but uses ctx to control its requests.
+func (s *PublicService) SellLocalContext(ctx context.Context, locationID int, slug string) ([]*Ad, *Response, error) {
+	return s.listAds(ctx, localAdsURL("sell-bitcoins-for-cash", locationID, slug))
+}
+
+func (s *PublicService) listAds(ctx context.Context, u string) ([]*Ad, *Response, error) {
+	it := NewIterator(u, s.client.Ads.listAds)
+	ads, err := it.AllContext(ctx)
+	if err != nil {
+		return nil, it.Response(), err
+	}
+
+	return ads, it.Response(), err
+}
+
+// Builds the URL of an online advertisement listing under base.
+func onlineAdsURL(base string, opt *PublicAdsOptions) (string, error) {
+	u := base + "/"
+	if opt == nil {
+		return u + ".json", nil
+	}
+
+	switch {
+	case opt.CountryCode != "" && opt.Currency != "":
+		return "", errors.New("localbitcoins: cannot filter public ads by both country and currency")
+	case opt.CountryCode != "":
+		if opt.CountryName == "" {
+			return "", errors.New("localbitcoins: CountryName is required to filter public ads by country")
+		}
+		u += url.PathEscape(opt.CountryCode) + "/" +
+			url.PathEscape(opt.CountryName) + "/"
+	case opt.Currency != "":
+		u += url.PathEscape(opt.Currency) + "/"
+	}
+	if opt.PaymentMethod != "" {
+		u += url.PathEscape(opt.PaymentMethod) + "/"
+	}
+
+	return u + ".json", nil
+}
+
+// Builds the URL of a local advertisement listing under base.
+func localAdsURL(base string, locationID int, slug string) string {
+	return fmt.Sprintf("%v/%v/%v/.json", base, locationID, url.PathEscape(slug))
+}
@@ -0,0 +1,122 @@
+package localbitcoins
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestPublicService_BuyOnline_pagination(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/buy-bitcoins-online/.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		switch r.URL.Query().Get("page") {
+		case "":
+			fmt.Fprintf(w, `{
+        "data":{"ad_list":[{"data":{"ad_id":1}}],"ad_count":1},
+        "pagination":{"next":"%v/buy-bitcoins-online/.json?page=2"}
+      }`, server.URL)
+		case "2":
+			fmt.Fprint(w, `{
+        "data":{"ad_list":[{"data":{"ad_id":2}}],"ad_count":1},
+        "pagination":{"prev":"/buy-bitcoins-online/.json"}
+      }`)
+		default:
+			t.Errorf("Unexpected page %v", r.URL.Query().Get("page"))
+		}
+	})
+
+	ads, _, err := client.Public.BuyOnline(nil)
+	if err != nil {
+		t.Errorf("Public.BuyOnline returned error: %v", err)
+	}
+
+	want := []*Ad{&Ad{ID: Int(1)}, &Ad{ID: Int(2)}}
+	if !reflect.DeepEqual(ads, want) {
+		t.Errorf("Public.BuyOnline returned %+v, want %+v", ads, want)
+	}
+}
+
+func TestPublicService_filters(t *testing.T) {
+	tests := []struct {
+		path string
+		list func() ([]*Ad, *Response, error)
+	}{
+		{"/buy-bitcoins-online/US/united-states/national-bank-transfer/.json", func() ([]*Ad, *Response, error) {
+			return client.Public.BuyOnline(&PublicAdsOptions{
+				CountryCode:   "US",
+				CountryName:   "united-states",
+				PaymentMethod: "national-bank-transfer",
+			})
+		}},
+		{"/sell-bitcoins-online/EUR/.json", func() ([]*Ad, *Response, error) {
+			return client.Public.SellOnline(&PublicAdsOptions{Currency: "EUR"})
+		}},
+		{"/sell-bitcoins-online/paypal/.json", func() ([]*Ad, *Response, error) {
+			return client.Public.SellOnline(&PublicAdsOptions{PaymentMethod: "paypal"})
+		}},
+		{"/buy-bitcoins-with-cash/1/helsinki-finland/.json", func() ([]*Ad, *Response, error) {
+			return client.Public.BuyLocal(1, "helsinki-finland")
+		}},
+		{"/sell-bitcoins-for-cash/1/helsinki-finland/.json", func() ([]*Ad, *Response, error) {
+			return client.Public.SellLocal(1, "helsinki-finland")
+		}},
+	}
+
+	for _, tt := range tests {
+		setup()
+
+		mux.HandleFunc(tt.path, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			fmt.Fprint(w, `{"data":{"ad_list":[{"data":{"ad_id":1}}],"ad_count":1}}`)
+		})
+
+		ads, _, err := tt.list()
+		if err != nil {
+			t.Errorf("%v returned error: %v", tt.path, err)
+		}
+		if want := []*Ad{&Ad{ID: Int(1)}}; !reflect.DeepEqual(ads, want) {
+			t.Errorf("%v returned %+v, want %+v", tt.path, ads, want)
+		}
+
+		teardown()
+	}
+}
+
+func TestPublicService_escapesPath(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/buy-bitcoins-online/", func(w http.ResponseWriter, r *http.Request) {
+		want := "/buy-bitcoins-online/US/united%20states/.json"
+		if got := r.URL.EscapedPath(); got != want {
+			t.Errorf("Request path: %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `{"data":{"ad_list":[],"ad_count":0}}`)
+	})
+
+	_, _, err := client.Public.BuyOnline(&PublicAdsOptions{
+		CountryCode: "US",
+		CountryName: "united states",
+	})
+	if err != nil {
+		t.Errorf("Public.BuyOnline returned error: %v", err)
+	}
+}
+
+func TestPublicService_invalidOptions(t *testing.T) {
+	c := NewClient(nil)
+
+	opts := []*PublicAdsOptions{
+		{CountryCode: "US", CountryName: "united-states", Currency: "USD"},
+		{CountryCode: "US"},
+	}
+	for _, opt := range opts {
+		if _, _, err := c.Public.BuyOnline(opt); err == nil {
+			t.Errorf("Public.BuyOnline(%+v) returned no error", opt)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package localbitcoins
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A RateLimiter delays requests to keep them within a rate limit. Wait blocks
+// until req may be sent, or returns an error if ctx is done first. path is the
+// URL path of req relative to the client's BaseURL, such as
+// "/api/wallet-send/", so that limiters can tell endpoints apart whatever path
+// BaseURL has.
+type RateLimiter interface {
+	Wait(ctx context.Context, req *http.Request, path string) error
+}
+
+// TokenBucket is a RateLimiter that allows bursts of up to Burst requests and
+// refills at Rate requests per second. It is safe for concurrent use.
+type TokenBucket struct {
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucket returns a full TokenBucket allowing rate requests per second
+// and bursts of up to burst requests. If rate is not positive, the bucket never
+// refills: once burst requests were made, Wait blocks until ctx is done.
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &TokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst)}
+}
+
+// Wait blocks until a token is available and takes it. If ctx is done first,
+// the token is returned to the bucket and ctx.Err() is returned.
+func (b *TokenBucket) Wait(ctx context.Context, req *http.Request, path string) error {
+	delay := b.reserve(time.Now())
+	if delay <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		b.mu.Lock()
+		b.tokens++
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// Takes a token from the bucket at the provided time, returning how long the
+// caller must wait before the token may be used.
+func (b *TokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.last.IsZero() {
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+	}
+	b.last = now
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	if b.rate <= 0 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// LimitGroup applies a TokenBucket to every request whose path, relative to
+// the client's BaseURL, starts with Prefix, such as "/api/wallet".
+type LimitGroup struct {
+	Prefix string
+	Bucket *TokenBucket
+}
+
+// GroupLimiter is a RateLimiter that limits groups of endpoints separately,
+// mirroring the separate limits LocalBitcoins applies to them. Requests are
+// limited by the first group whose prefix matches their path, or by Default if
+// none does. Requests matching no group are not limited if Default is nil.
+type GroupLimiter struct {
+	Groups  []LimitGroup
+	Default *TokenBucket
+}
+
+// Wait blocks until req, whose path is relative to the client's BaseURL, may
+// be sent according to the group it belongs to.
+func (l *GroupLimiter) Wait(ctx context.Context, req *http.Request, path string) error {
+	for _, g := range l.Groups {
+		if strings.HasPrefix(path, g.Prefix) {
+			return g.Bucket.Wait(ctx, req, path)
+		}
+	}
+	if l.Default != nil {
+		return l.Default.Wait(ctx, req, path)
+	}
+	return nil
+}
+
+// Returns the URL path of req with the path of base, if any, removed, so that
+// it can be matched against API paths such as "/api/wallet-send/".
+func apiPath(base *url.URL, req *http.Request) string {
+	path := req.URL.Path
+	if base == nil {
+		return path
+	}
+	prefix := strings.TrimSuffix(base.Path, "/")
+	if prefix != "" && strings.HasPrefix(path, prefix+"/") {
+		return path[len(prefix):]
+	}
+	return path
+}
+
+// Parses the value of a Retry-After header, which is either a number of
+// seconds or an HTTP date, into the duration to wait from now. Returns zero if
+// the value is empty or cannot be parsed.
+func parseRetryAfter(v string, now time.Time) time.Duration {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
@@ -0,0 +1,206 @@
+package localbitcoins
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket_reserve(t *testing.T) {
+	b := NewTokenBucket(2, 2)
+	now := time.Now()
+
+	// the burst is available immediately
+	for i := 0; i < 2; i++ {
+		if d := b.reserve(now); d != 0 {
+			t.Errorf("reserve %d waited %v, want 0", i, d)
+		}
+	}
+
+	// then tokens are handed out at the refill rate
+	if d, want := b.reserve(now), 500*time.Millisecond; d != want {
+		t.Errorf("reserve waited %v, want %v", d, want)
+	}
+	if d, want := b.reserve(now), time.Second; d != want {
+		t.Errorf("reserve waited %v, want %v", d, want)
+	}
+
+	// and the bucket never refills past its burst
+	if d := b.reserve(now.Add(time.Hour)); d != 0 {
+		t.Errorf("reserve after refill waited %v, want 0", d)
+	}
+	if b.tokens != 1 {
+		t.Errorf("tokens = %v, want 1", b.tokens)
+	}
+}
+
+func TestTokenBucket_Wait_concurrent(t *testing.T) {
+	b := NewTokenBucket(100, 1)
+	req, _ := http.NewRequest("GET", "/", nil)
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := b.Wait(context.Background(), req, req.URL.Path); err != nil {
+				t.Errorf("Wait returned error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// one request is allowed immediately, the other four at 10ms intervals
+	if elapsed, min := time.Since(start), 40*time.Millisecond; elapsed < min {
+		t.Errorf("5 requests took %v, want at least %v", elapsed, min)
+	}
+}
+
+func TestTokenBucket_Wait_canceled(t *testing.T) {
+	b := NewTokenBucket(0.001, 1)
+	req, _ := http.NewRequest("GET", "/", nil)
+	b.Wait(context.Background(), req, req.URL.Path)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := b.Wait(ctx, req, req.URL.Path); err != context.DeadlineExceeded {
+		t.Errorf("Wait returned error %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestTokenBucket_Wait_canceledFull(t *testing.T) {
+	b := NewTokenBucket(0.001, 1)
+	req, _ := http.NewRequest("GET", "/", nil)
+	b.Wait(context.Background(), req, req.URL.Path)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- b.Wait(ctx, req, req.URL.Path) }()
+
+	// refill the bucket while the request waits, then cancel it
+	for {
+		b.mu.Lock()
+		waiting := b.tokens < 0
+		if waiting {
+			b.tokens = b.burst
+		}
+		b.mu.Unlock()
+		if waiting {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	<-done
+
+	if b.tokens > b.burst {
+		t.Errorf("Canceled Wait left %v tokens, want at most %v", b.tokens, b.burst)
+	}
+}
+
+func TestTokenBucket_Wait_zeroRate(t *testing.T) {
+	b := NewTokenBucket(0, 2)
+	req, _ := http.NewRequest("GET", "/", nil)
+	for i := 0; i < 2; i++ {
+		if err := b.Wait(context.Background(), req, req.URL.Path); err != nil {
+			t.Fatalf("Wait %d returned error: %v", i, err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := b.Wait(ctx, req, req.URL.Path); err != context.DeadlineExceeded {
+		t.Errorf("Wait after the burst returned error %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestGroupLimiter(t *testing.T) {
+	wallet := NewTokenBucket(1, 1)
+	def := NewTokenBucket(1, 1)
+	l := &GroupLimiter{
+		Groups:  []LimitGroup{{Prefix: "/api/wallet", Bucket: wallet}},
+		Default: def,
+	}
+
+	req, _ := http.NewRequest("GET", "https://localbitcoins.com/api/wallet-balance/", nil)
+	l.Wait(context.Background(), req, req.URL.Path)
+	if wallet.tokens != 0 || def.tokens != 1 {
+		t.Errorf("Wallet request took tokens %v and %v, want 0 and 1", wallet.tokens, def.tokens)
+	}
+
+	req, _ = http.NewRequest("GET", "https://localbitcoins.com/api/myself/", nil)
+	l.Wait(context.Background(), req, req.URL.Path)
+	if def.tokens != 0 {
+		t.Errorf("Other request left %v default tokens, want 0", def.tokens)
+	}
+
+	if err := (&GroupLimiter{}).Wait(context.Background(), req, req.URL.Path); err != nil {
+		t.Errorf("Wait without groups returned error: %v", err)
+	}
+}
+
+func TestClient_RateLimiter_baseURLPath(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/proxy/api/wallet-balance/", func(w http.ResponseWriter, r *http.Request) {})
+
+	wallet := NewTokenBucket(1, 1)
+	client.BaseURL, _ = url.Parse(server.URL + "/proxy/")
+	client.RateLimiter = &GroupLimiter{
+		Groups: []LimitGroup{{Prefix: "/api/wallet", Bucket: wallet}},
+	}
+	req, _ := client.NewRequest("GET", "api/wallet-balance/", nil)
+	if _, err := client.Do(req, nil); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if wallet.tokens != 0 {
+		t.Errorf("Wallet request left %v wallet tokens, want 0", wallet.tokens)
+	}
+}
+
+func TestClient_RateLimiter(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {})
+
+	client.RateLimiter = NewTokenBucket(0.001, 1)
+	req, _ := client.NewRequest("GET", "/", nil)
+	if _, err := client.Do(req, nil); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+
+	// the second request must wait far longer than the context allows
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req, _ = client.NewRequest("GET", "/", nil)
+	if _, err := client.DoContext(ctx, req, nil); err != context.DeadlineExceeded {
+		t.Errorf("DoContext returned error %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2016, 6, 25, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"", 0},
+		{"30", 30 * time.Second},
+		{"-1", 0},
+		{"Sat, 25 Jun 2016 12:01:00 GMT", time.Minute},
+		{"Sat, 25 Jun 2016 11:59:00 GMT", 0},
+		{"soon", 0},
+	}
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.in, now); got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
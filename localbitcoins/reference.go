@@ -0,0 +1,274 @@
+package localbitcoins
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ReferenceService handles communications with the reference data endpoints
+// of the LocalBitcoins API, which list the currencies, countries, payment
+// methods and places known to LocalBitcoins.
+//
+// As this data changes rarely, ReferenceService can cache it in memory; see
+// CacheTTL.
+type ReferenceService struct {
+	client *Client
+
+	// CacheTTL is how long results are cached for. Zero, the default, disables
+	// caching. CacheTTL should be set before the service is used. Results
+	// served from the cache come with a nil Response.
+	CacheTTL time.Duration
+
+	mu    sync.Mutex
+	cache map[string]referenceCacheEntry
+}
+
+type referenceCacheEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+// Currency represents a currency as returned by the LocalBitcoins API.
+type Currency struct {
+	Code    *string `json:"code,omitempty"`
+	Name    *string `json:"name,omitempty"`
+	Altcoin *bool   `json:"altcoin,omitempty"`
+}
+
+func (c Currency) String() string {
+	return Stringify(c)
+}
+
+// Country represents a country as returned by the LocalBitcoins API.
+type Country struct {
+	Code *string `json:"code,omitempty"` // two letter country code, such as "US"
+}
+
+func (c Country) String() string {
+	return Stringify(c)
+}
+
+// PaymentMethod represents an online payment method as returned by the
+// LocalBitcoins API.
+type PaymentMethod struct {
+	Code          *string  `json:"code,omitempty"`
+	Name          *string  `json:"name,omitempty"`
+	Currencies    []string `json:"currencies,omitempty"`
+	BuyOnlineURL  *string  `json:"buy_bitcoins_online_url,omitempty"`
+	SellOnlineURL *string  `json:"sell_bitcoins_online_url,omitempty"`
+}
+
+func (m PaymentMethod) String() string {
+	return Stringify(m)
+}
+
+// Place represents a place with local advertisements, as returned by the
+// LocalBitcoins API.
+type Place struct {
+	URL            *string  `json:"url,omitempty"`
+	BuyLocalURL    *string  `json:"buy_local_url,omitempty"`
+	SellLocalURL   *string  `json:"sell_local_url,omitempty"`
+	LocationString *string  `json:"location_string,omitempty"`
+	Lat            *float64 `json:"lat,omitempty"`
+	Lon            *float64 `json:"lon,omitempty"`
+}
+
+func (p Place) String() string {
+	return Stringify(p)
+}
+
+// Currency list middleman used strictly for unmarshaling the API response.
+type currencyListMiddleman struct {
+	Currencies map[string]*Currency `json:"currencies,omitempty"`
+}
+
+// Country code list middleman used strictly for unmarshaling the API response.
+type countryListMiddleman struct {
+	Codes []string `json:"cc_list,omitempty"`
+}
+
+// Payment method list middleman used strictly for unmarshaling the API
+// response.
+type paymentMethodListMiddleman struct {
+	Methods map[string]*PaymentMethod `json:"methods,omitempty"`
+}
+
+// Place list middleman used strictly for unmarshaling the API response.
+type placeListMiddleman struct {
+	Places []*Place `json:"places,omitempty"`
+}
+
+// placeOptions specifies the parameters to the places endpoint.
+type placeOptions struct {
+	Lat float64 `url:"lat"`
+	Lon float64 `url:"lon"`
+}
+
+// Currencies lists the currencies known to LocalBitcoins, sorted by code.
+func (s *ReferenceService) Currencies() ([]*Currency, *Response, error) {
+	return s.CurrenciesContext(context.Background())
+}
+
+// CurrenciesContext is like Currencies but uses ctx to control its requests.
+func (s *ReferenceService) CurrenciesContext(ctx context.Context) ([]*Currency, *Response, error) {
+	return referenceGet(ctx, s, "api/currencies/", func(m *currencyListMiddleman) []*Currency {
+		codes := sortedKeys(m.Currencies)
+		currencies := make([]*Currency, len(codes))
+		for i, code := range codes {
+			c := m.Currencies[code]
+			if c == nil {
+				c = new(Currency)
+			}
+			c.Code = String(code)
+			currencies[i] = c
+		}
+		return currencies
+	})
+}
+
+// Countries lists the countries known to LocalBitcoins, in the order returned
+// by the API.
+func (s *ReferenceService) Countries() ([]*Country, *Response, error) {
+	return s.CountriesContext(context.Background())
+}
+
+// CountriesContext is like Countries but uses ctx to control its requests.
+func (s *ReferenceService) CountriesContext(ctx context.Context) ([]*Country, *Response, error) {
+	return referenceGet(ctx, s, "api/countrycodes/", func(m *countryListMiddleman) []*Country {
+		countries := make([]*Country, len(m.Codes))
+		for i, code := range m.Codes {
+			countries[i] = &Country{Code: String(code)}
+		}
+		return countries
+	})
+}
+
+// PaymentMethods lists the online payment methods known to LocalBitcoins,
+// sorted by code. If countryCode is not empty, only the payment methods
+// available in that country are listed.
+func (s *ReferenceService) PaymentMethods(countryCode string) ([]*PaymentMethod, *Response, error) {
+	return s.PaymentMethodsContext(context.Background(), countryCode)
+}
+
+// PaymentMethodsContext is like PaymentMethods but uses ctx to control its
+// requests.
+func (s *ReferenceService) PaymentMethodsContext(ctx context.Context, countryCode string) ([]*PaymentMethod, *Response, error) {
+	u := "api/payment_methods/"
+	if countryCode != "" {
+		u = fmt.Sprintf("api/payment_methods/%v/", url.PathEscape(countryCode))
+	}
+
+	return referenceGet(ctx, s, u, func(m *paymentMethodListMiddleman) []*PaymentMethod {
+		codes := sortedKeys(m.Methods)
+		methods := make([]*PaymentMethod, len(codes))
+		for i, code := range codes {
+			pm := m.Methods[code]
+			if pm == nil {
+				pm = new(PaymentMethod)
+			}
+			if pm.Code == nil {
+				pm.Code = String(code)
+			}
+			methods[i] = pm
+		}
+		return methods
+	})
+}
+
+// Places lists the places with local advertisements nearest to the provided
+// latitude and longitude. Their URLs can be used with PublicService.BuyLocal
+// and PublicService.SellLocal.
+func (s *ReferenceService) Places(lat, lon float64) ([]*Place, *Response, error) {
+	return s.PlacesContext(context.Background(), lat, lon)
+}
+
+// PlacesContext is like Places but uses ctx to control its requests.
+func (s *ReferenceService) PlacesContext(ctx context.Context, lat, lon float64) ([]*Place, *Response, error) {
+	u, err := addOptions("api/places/", &placeOptions{Lat: lat, Lon: lon})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return referenceGet(ctx, s, u, func(m *placeListMiddleman) []*Place {
+		return m.Places
+	})
+}
+
+// ClearCache removes every cached result.
+func (s *ReferenceService) ClearCache() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cache = nil
+}
+
+// Fetches the list at u by decoding the response data into a new M and
+// converting it with conv, or returns the list cached by a previous call if it
+// has not expired. The returned slice is a copy, but its elements are shared
+// with the cache.
+func referenceGet[M any, T any](ctx context.Context, s *ReferenceService, u string,
+	conv func(*M) []T) ([]T, *Response, error) {
+	if v, ok := s.cached(u, time.Now()); ok {
+		return append([]T(nil), v.([]T)...), nil, nil
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	middleman := new(M)
+	respMiddleman := &ResponseData{Data: middleman}
+	resp, err := s.client.DoContext(ctx, req, respMiddleman)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	list := conv(middleman)
+	s.store(u, list, time.Now())
+	return append([]T(nil), list...), resp, err
+}
+
+// Returns the cached value for u, if any and not expired at now.
+func (s *ReferenceService) cached(u string, now time.Time) (interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.cache[u]
+	if !ok {
+		return nil, false
+	}
+	if !now.Before(e.expires) {
+		delete(s.cache, u)
+		return nil, false
+	}
+	return e.value, true
+}
+
+// Caches v for u for CacheTTL from now, if caching is enabled.
+func (s *ReferenceService) store(u string, v interface{}, now time.Time) {
+	if s.CacheTTL <= 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cache == nil {
+		s.cache = make(map[string]referenceCacheEntry)
+	}
+	s.cache[u] = referenceCacheEntry{value: v, expires: now.Add(s.CacheTTL)}
+}
+
+// Returns the keys of m in sorted order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,233 @@
+package localbitcoins
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestReferenceService_Currencies(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/currencies/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{
+      "data":{
+        "currencies":{
+          "USD":{"name":"United States dollar","altcoin":false},
+          "ETH":{"name":"Ethereum","altcoin":true},
+          "EUR":{"name":"Euro","altcoin":false}
+        },
+        "currency_count":3
+      }
+    }`)
+	})
+
+	currencies, _, err := client.Reference.Currencies()
+	if err != nil {
+		t.Errorf("Reference.Currencies returned error: %v", err)
+	}
+
+	want := []*Currency{
+		{Code: String("ETH"), Name: String("Ethereum"), Altcoin: Bool(true)},
+		{Code: String("EUR"), Name: String("Euro"), Altcoin: Bool(false)},
+		{Code: String("USD"), Name: String("United States dollar"), Altcoin: Bool(false)},
+	}
+	if !reflect.DeepEqual(currencies, want) {
+		t.Errorf("Reference.Currencies returned %+v, want %+v", currencies, want)
+	}
+}
+
+func TestReferenceService_Countries(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/countrycodes/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data":{"cc_list":["US","FI"],"cc_count":2}}`)
+	})
+
+	countries, _, err := client.Reference.Countries()
+	if err != nil {
+		t.Errorf("Reference.Countries returned error: %v", err)
+	}
+
+	want := []*Country{{Code: String("US")}, {Code: String("FI")}}
+	if !reflect.DeepEqual(countries, want) {
+		t.Errorf("Reference.Countries returned %+v, want %+v", countries, want)
+	}
+}
+
+func TestReferenceService_PaymentMethods(t *testing.T) {
+	tests := []struct {
+		countryCode string
+		path        string
+	}{
+		{"", "/api/payment_methods/"},
+		{"FI", "/api/payment_methods/FI/"},
+	}
+
+	for _, tt := range tests {
+		setup()
+
+		mux.HandleFunc(tt.path, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			fmt.Fprint(w, `{
+        "data":{
+          "methods":{
+            "SEPA":{
+              "code":"SEPA",
+              "name":"SEPA (EU) bank transfer",
+              "currencies":["EUR"],
+              "buy_bitcoins_online_url":"https://localbitcoins.com/buy-bitcoins-online/sepa-eu-bank-transfer/"
+            },
+            "CASH_BY_MAIL":{"name":"Cash by mail","currencies":["EUR","USD"]}
+          },
+          "method_count":2
+        }
+      }`)
+		})
+
+		methods, _, err := client.Reference.PaymentMethods(tt.countryCode)
+		if err != nil {
+			t.Errorf("Reference.PaymentMethods(%q) returned error: %v", tt.countryCode, err)
+		}
+
+		want := []*PaymentMethod{
+			{
+				Code:       String("CASH_BY_MAIL"),
+				Name:       String("Cash by mail"),
+				Currencies: []string{"EUR", "USD"},
+			},
+			{
+				Code:         String("SEPA"),
+				Name:         String("SEPA (EU) bank transfer"),
+				Currencies:   []string{"EUR"},
+				BuyOnlineURL: String("https://localbitcoins.com/buy-bitcoins-online/sepa-eu-bank-transfer/"),
+			},
+		}
+		if !reflect.DeepEqual(methods, want) {
+			t.Errorf("Reference.PaymentMethods(%q) returned %+v, want %+v",
+				tt.countryCode, methods, want)
+		}
+
+		teardown()
+	}
+}
+
+func TestReferenceService_PaymentMethods_escapesCountryCode(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/payment_methods/", func(w http.ResponseWriter, r *http.Request) {
+		if want := "/api/payment_methods/F%2FI/"; r.URL.EscapedPath() != want {
+			t.Errorf("Request path: %v, want %v", r.URL.EscapedPath(), want)
+		}
+		fmt.Fprint(w, `{"data":{"methods":{}}}`)
+	})
+
+	if _, _, err := client.Reference.PaymentMethods("F/I"); err != nil {
+		t.Errorf("Reference.PaymentMethods returned error: %v", err)
+	}
+}
+
+func TestReferenceService_Places(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/places/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"lat": "60.17", "lon": "24.94"})
+		fmt.Fprint(w, `{
+      "data":{
+        "places":[{
+          "url":"https://localbitcoins.com/api/places/?lat=60.17&lon=24.94",
+          "buy_local_url":"https://localbitcoins.com/buy-bitcoins-with-cash/1/helsinki/",
+          "sell_local_url":"https://localbitcoins.com/sell-bitcoins-for-cash/1/helsinki/",
+          "location_string":"Helsinki, Finland",
+          "lat":60.17,
+          "lon":24.94
+        }],
+        "place_count":1
+      }
+    }`)
+	})
+
+	places, _, err := client.Reference.Places(60.17, 24.94)
+	if err != nil {
+		t.Errorf("Reference.Places returned error: %v", err)
+	}
+
+	want := []*Place{{
+		URL:            String("https://localbitcoins.com/api/places/?lat=60.17&lon=24.94"),
+		BuyLocalURL:    String("https://localbitcoins.com/buy-bitcoins-with-cash/1/helsinki/"),
+		SellLocalURL:   String("https://localbitcoins.com/sell-bitcoins-for-cash/1/helsinki/"),
+		LocationString: String("Helsinki, Finland"),
+		Lat:            Float(60.17),
+		Lon:            Float(24.94),
+	}}
+	if !reflect.DeepEqual(places, want) {
+		t.Errorf("Reference.Places returned %+v, want %+v", places, want)
+	}
+}
+
+func TestReferenceService_cache(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests int
+	mux.HandleFunc("/api/countrycodes/", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `{"data":{"cc_list":["US"]}}`)
+	})
+
+	client.Reference.CacheTTL = time.Hour
+	for i := 0; i < 3; i++ {
+		countries, resp, err := client.Reference.Countries()
+		if err != nil {
+			t.Fatalf("Reference.Countries returned error: %v", err)
+		}
+		if len(countries) != 1 || countries[0] == nil {
+			t.Fatalf("Reference.Countries returned %+v, want one country", countries)
+		}
+		if cached := i > 0; cached != (resp == nil) {
+			t.Errorf("call %v: Reference.Countries returned Response %v", i, resp)
+		}
+		// modifying the returned slice must not affect the cache
+		countries[0] = nil
+	}
+	if requests != 1 {
+		t.Errorf("Reference.Countries made %v requests, want 1", requests)
+	}
+
+	client.Reference.ClearCache()
+	client.Reference.Countries()
+	if requests != 2 {
+		t.Errorf("Reference.Countries made %v requests after ClearCache, want 2", requests)
+	}
+}
+
+func TestReferenceService_cacheExpiry(t *testing.T) {
+	s := &ReferenceService{CacheTTL: time.Minute}
+	now := time.Now()
+	s.store("u", "v", now)
+
+	if v, ok := s.cached("u", now.Add(59*time.Second)); !ok || v != "v" {
+		t.Errorf("cached before expiry = %v, %v, want v, true", v, ok)
+	}
+	if _, ok := s.cached("u", now.Add(time.Minute)); ok {
+		t.Errorf("cached after expiry returned a value")
+	}
+}
+
+func TestReferenceService_cacheDisabled(t *testing.T) {
+	s := new(ReferenceService)
+	s.store("u", "v", time.Now())
+
+	if _, ok := s.cached("u", time.Now()); ok {
+		t.Errorf("cached returned a value with caching disabled")
+	}
+}
@@ -0,0 +1,167 @@
+package localbitcoins
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// RetryPolicy describes how a Client retries requests that failed because of
+// transient errors, such as network failures or 5xx responses. Retries are
+// delayed using exponential backoff with jitter. Requests whose response was
+// received but could not be read or decoded are never retried.
+//
+// By default only requests with safe methods, such as GET, are retried. Other
+// requests may have taken effect even though they failed, so they are only
+// retried if RetryUnsafe is set. Even then, requests that move money, such as
+// releasing an escrow or sending bitcoin from the wallet, are only retried if
+// RetryMoneyMoving is set as well.
+type RetryPolicy struct {
+	// Maximum number of attempts made for a request, including the first one.
+	MaxAttempts int
+
+	// Backoff before the first retry, doubled for every following retry up to
+	// MaxBackoff. If zero, 500ms and 30s are used respectively.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// RetryableStatus reports whether a response with the provided status code
+	// should be retried. If nil, DefaultRetryableStatus is used.
+	RetryableStatus func(code int) bool
+
+	// Whether requests with unsafe methods, such as POST, are retried.
+	RetryUnsafe bool
+
+	// Whether requests that move money are retried. Only takes effect if
+	// RetryUnsafe is also set.
+	RetryMoneyMoving bool
+}
+
+// NewRetryPolicy returns a RetryPolicy making up to maxAttempts attempts for
+// each request, with the default backoff and retryable statuses.
+func NewRetryPolicy(maxAttempts int) *RetryPolicy {
+	return &RetryPolicy{MaxAttempts: maxAttempts}
+}
+
+// DefaultRetryableStatus reports whether code is a status code that usually
+// indicates a transient error: 429 Too Many Requests, 500 Internal Server
+// Error, 502 Bad Gateway, 503 Service Unavailable or 504 Gateway Timeout.
+func DefaultRetryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusInternalServerError,
+		http.StatusBadGateway, http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// Path prefixes of the endpoints that move money.
+var moneyMovingPaths = []string{
+	"/api/escrow_release/",
+	"/api/contact_release/",
+	"/api/contact_release_pin/",
+	"/api/contact_fund/",
+	"/api/contact_create/",
+	"/api/wallet-send/",
+	"/api/wallet-send-pin/",
+}
+
+// Reports whether req, sent to the API at base, is a request to an endpoint
+// that moves money.
+func isMoneyMoving(base *url.URL, req *http.Request) bool {
+	path := apiPath(base, req)
+	for _, p := range moneyMovingPaths {
+		if strings.HasPrefix(path, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// Reports whether method is safe, and therefore idempotent.
+func isSafeMethod(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS", "TRACE":
+		return true
+	}
+	return false
+}
+
+// Reports whether req, which was sent to the API at base and failed with err
+// and resp on the provided attempt, should be retried.
+func (p *RetryPolicy) shouldRetry(base *url.URL, req *http.Request, resp *Response,
+	err error, attempt int) bool {
+	if err == nil || attempt >= p.MaxAttempts {
+		return false
+	}
+	if !isSafeMethod(req.Method) {
+		if !p.RetryUnsafe || (isMoneyMoving(base, req) && !p.RetryMoneyMoving) {
+			return false
+		}
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		// the body can't be sent again
+		return false
+	}
+
+	retryable := p.RetryableStatus
+	if retryable == nil {
+		retryable = DefaultRetryableStatus
+	}
+
+	switch err := err.(type) {
+	case *ErrorResponse:
+		return retryable(err.Response.StatusCode)
+	case *RateLimitError:
+		return retryable(err.Response.StatusCode)
+	case *UpstreamError:
+		return retryable(err.Response.StatusCode)
+	}
+	if resp != nil {
+		// the request went through, but its response couldn't be read or
+		// decoded, which sending it again won't fix
+		return false
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	// failures to send the request or receive its response are transient
+	var urlErr *url.Error
+	var netErr net.Error
+	return errors.As(err, &urlErr) || errors.As(err, &netErr)
+}
+
+// Returns how long to wait before the retry following the provided attempt,
+// which failed with err.
+func (p *RetryPolicy) backoff(attempt int, err error) time.Duration {
+	min, max := p.MinBackoff, p.MaxBackoff
+	if min <= 0 {
+		min = 500 * time.Millisecond
+	}
+	if max <= 0 {
+		max = 30 * time.Second
+	}
+
+	d := min
+	for i := 1; i < attempt && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	// wait somewhere between half and all of the backoff, so that clients
+	// failing together don't retry together
+	d = d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+
+	// never retry sooner than the API asked
+	if rle, ok := err.(*RateLimitError); ok && rle.RetryAfter > d {
+		d = rle.RetryAfter
+	}
+	return d
+}
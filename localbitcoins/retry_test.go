@@ -0,0 +1,243 @@
+package localbitcoins
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// Registers a handler on path that fails with status for the first failures
+// requests and succeeds afterwards, returning a pointer to the number of
+// requests received.
+func handleFlaky(t *testing.T, path string, failures, status int) *int {
+	count := new(int)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		*count++
+		if body, _ := ioutil.ReadAll(r.Body); r.Method == "POST" && string(body) != "a=1" {
+			t.Errorf("Attempt %d body = %q, want %q", *count, body, "a=1")
+		}
+		if *count <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		fmt.Fprint(w, `{"data":{}}`)
+	})
+	return count
+}
+
+func testRetries(t *testing.T, method, path string, wantErr bool) {
+	var body interface{}
+	if method == "POST" {
+		body = &struct {
+			A int `url:"a"`
+		}{1}
+	}
+	req, _ := client.NewFormRequest(method, path, body)
+	_, err := client.Do(req, &ResponseData{})
+	if wantErr && err == nil {
+		t.Errorf("%v %v: expected error to be returned", method, path)
+	}
+	if !wantErr && err != nil {
+		t.Errorf("%v %v returned error: %v", method, path, err)
+	}
+}
+
+func TestRetryPolicy_safeMethod(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.RetryPolicy = &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond}
+	count := handleFlaky(t, "/api/myself/", 2, http.StatusServiceUnavailable)
+
+	testRetries(t, "GET", "/api/myself/", false)
+	if *count != 3 {
+		t.Errorf("Server received %d requests, want 3", *count)
+	}
+}
+
+func TestRetryPolicy_maxAttempts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.RetryPolicy = &RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond}
+	count := handleFlaky(t, "/api/myself/", 5, http.StatusBadGateway)
+
+	testRetries(t, "GET", "/api/myself/", true)
+	if *count != 2 {
+		t.Errorf("Server received %d requests, want 2", *count)
+	}
+}
+
+func TestRetryPolicy_nonRetryableStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.RetryPolicy = &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond}
+	count := handleFlaky(t, "/api/myself/", 1, http.StatusBadRequest)
+
+	testRetries(t, "GET", "/api/myself/", true)
+	if *count != 1 {
+		t.Errorf("Server received %d requests, want 1", *count)
+	}
+}
+
+func TestRetryPolicy_unsafeMethods(t *testing.T) {
+	tests := []struct {
+		path             string
+		retryUnsafe      bool
+		retryMoneyMoving bool
+		want             int
+	}{
+		{"/api/ad-create/", false, false, 1},
+		{"/api/ad-create/", true, false, 2},
+		{"/api/wallet-send/", true, false, 1},
+		{"/api/escrow_release/1/", true, false, 1},
+		{"/api/contact_release_pin/1/", true, false, 1},
+		{"/api/wallet-send/", false, true, 1},
+		{"/api/wallet-send/", true, true, 2},
+	}
+
+	for _, tt := range tests {
+		setup()
+
+		client.RetryPolicy = &RetryPolicy{
+			MaxAttempts:      2,
+			MinBackoff:       time.Millisecond,
+			RetryUnsafe:      tt.retryUnsafe,
+			RetryMoneyMoving: tt.retryMoneyMoving,
+		}
+		count := handleFlaky(t, tt.path, 1, http.StatusInternalServerError)
+
+		testRetries(t, "POST", tt.path, tt.want == 1)
+		if *count != tt.want {
+			t.Errorf("POST %v with %+v: server received %d requests, want %d",
+				tt.path, client.RetryPolicy, *count, tt.want)
+		}
+
+		teardown()
+	}
+}
+
+func TestRetryPolicy_moneyMovingBaseURLPath(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.BaseURL, _ = url.Parse(server.URL + "/proxy/")
+	client.RetryPolicy = &RetryPolicy{
+		MaxAttempts: 2,
+		MinBackoff:  time.Millisecond,
+		RetryUnsafe: true,
+	}
+	count := handleFlaky(t, "/proxy/api/wallet-send/", 1, http.StatusInternalServerError)
+
+	testRetries(t, "POST", "api/wallet-send/", true)
+	if *count != 1 {
+		t.Errorf("Server received %d requests, want 1", *count)
+	}
+}
+
+func TestRetryPolicy_decodeError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.RetryPolicy = &RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+		RetryUnsafe: true,
+	}
+	count := 0
+	mux.HandleFunc("/api/ad-create/", func(w http.ResponseWriter, r *http.Request) {
+		count++
+		fmt.Fprint(w, `{"data":`)
+	})
+
+	testRetries(t, "POST", "/api/ad-create/", true)
+	if count != 1 {
+		t.Errorf("Server received %d requests, want 1", count)
+	}
+}
+
+func TestRetryPolicy_networkError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.RetryPolicy = &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond}
+	count := 0
+	mux.HandleFunc("/api/myself/", func(w http.ResponseWriter, r *http.Request) {
+		count++
+		if count == 1 {
+			// drop the connection without responding
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Fatalf("Hijack returned error: %v", err)
+			}
+			conn.Close()
+			return
+		}
+		fmt.Fprint(w, `{"data":{}}`)
+	})
+
+	testRetries(t, "GET", "/api/myself/", false)
+	if count != 2 {
+		t.Errorf("Server received %d requests, want 2", count)
+	}
+}
+
+func TestRetryPolicy_canceledDuringBackoff(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.RetryPolicy = &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Hour}
+	handleFlaky(t, "/api/myself/", 5, http.StatusServiceUnavailable)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req, _ := client.NewRequest("GET", "/api/myself/", nil)
+	_, err := client.DoContext(ctx, req, nil)
+	if err != context.DeadlineExceeded {
+		t.Errorf("DoContext returned error %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	p := &RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	tests := []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{1, 50 * time.Millisecond, 100 * time.Millisecond},
+		{2, 100 * time.Millisecond, 200 * time.Millisecond},
+		{3, 200 * time.Millisecond, 400 * time.Millisecond},
+		{10, 500 * time.Millisecond, time.Second},
+	}
+	for _, tt := range tests {
+		for i := 0; i < 20; i++ {
+			if d := p.backoff(tt.attempt, nil); d < tt.min || d > tt.max {
+				t.Errorf("backoff(%d) = %v, want between %v and %v", tt.attempt, d, tt.min, tt.max)
+			}
+		}
+	}
+
+	err := &RateLimitError{RetryAfter: 5 * time.Second}
+	if d := p.backoff(1, err); d != 5*time.Second {
+		t.Errorf("backoff after rate limit = %v, want %v", d, 5*time.Second)
+	}
+}
+
+func TestDefaultRetryableStatus(t *testing.T) {
+	for _, code := range []int{429, 500, 502, 503, 504} {
+		if !DefaultRetryableStatus(code) {
+			t.Errorf("DefaultRetryableStatus(%d) = false, want true", code)
+		}
+	}
+	for _, code := range []int{200, 400, 401, 403, 404, 501} {
+		if DefaultRetryableStatus(code) {
+			t.Errorf("DefaultRetryableStatus(%d) = true, want false", code)
+		}
+	}
+}
@@ -20,6 +20,8 @@ func Stringify(message interface{}) string {
 	return buf.String()
 }
 
+var amountType = reflect.TypeOf(Amount{})
+
 // stringifyValue was heavily inspired by the goprotobuf library.
 
 func stringifyValue(w io.Writer, val reflect.Value) {
@@ -30,6 +32,12 @@ func stringifyValue(w io.Writer, val reflect.Value) {
 
 	v := reflect.Indirect(val)
 
+	// amounts are only meaningful in their decimal form
+	if v.IsValid() && v.Type() == amountType && v.CanInterface() {
+		fmt.Fprint(w, v.Interface())
+		return
+	}
+
 	switch v.Kind() {
 	case reflect.String:
 		fmt.Fprintf(w, `"%s"`, v)
@@ -48,6 +48,15 @@ func TestStringify(t *testing.T) {
 			`["a" "b"]`,
 		},
 
+		// amounts
+		{MustParseAmount("0.10"), `0.10`},
+		{
+			struct {
+				A *Amount
+			}{amountPtr("-1.5")},
+			`{A:-1.5}`,
+		},
+
 		// TODO: add actual LocalBitcoins structs
 	}
 
@@ -0,0 +1,223 @@
+package localbitcoins
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// WalletService handles all wallet-related communications with the
+// LocalBitcoins API.
+type WalletService struct {
+	client *Client
+}
+
+// Wallet represents the bitcoin wallet of the authenticated account.
+type Wallet struct {
+	Message               *string             `json:"message,omitempty"`
+	Total                 *WalletBalance      `json:"total,omitempty"`
+	SentTransactions      []*Transaction      `json:"sent_transactions_30d,omitempty"`
+	ReceivedTransactions  []*Transaction      `json:"received_transactions_30d,omitempty"`
+	ReceivingAddressCount *int                `json:"receiving_address_count,omitempty"`
+	ReceivingAddresses    []*ReceivingAddress `json:"receiving_address_list,omitempty"`
+}
+
+func (w Wallet) String() string {
+	return Stringify(w)
+}
+
+// WalletBalance represents the balance of a wallet, in bitcoin.
+type WalletBalance struct {
+	Balance  *Amount `json:"balance,omitempty"`
+	Sendable *Amount `json:"sendable,omitempty"`
+}
+
+func (b WalletBalance) String() string {
+	return Stringify(b)
+}
+
+// ReceivingAddress represents a bitcoin address of a wallet, along with the
+// amount of bitcoin it has received.
+type ReceivingAddress struct {
+	Address  *string `json:"address,omitempty"`
+	Received *Amount `json:"received,omitempty"`
+}
+
+func (a ReceivingAddress) String() string {
+	return Stringify(a)
+}
+
+// TransactionType is the type of a wallet transaction.
+type TransactionType int
+
+// Transaction types returned by the LocalBitcoins API.
+const (
+	TransactionSend TransactionType = iota + 1
+	TransactionReceive
+	TransactionOther
+)
+
+func (t TransactionType) String() string {
+	switch t {
+	case TransactionSend:
+		return "send"
+	case TransactionReceive:
+		return "receive"
+	case TransactionOther:
+		return "other"
+	}
+	return fmt.Sprintf("TransactionType(%d)", int(t))
+}
+
+// Transaction represents a transaction of a wallet.
+type Transaction struct {
+	TxID        *string          `json:"txid,omitempty"`
+	Amount      *Amount          `json:"amount,omitempty"`
+	Description *string          `json:"description,omitempty"`
+	Type        *TransactionType `json:"tx_type,omitempty"`
+	CreatedAt   *time.Time       `json:"created_at,omitempty"`
+}
+
+func (t Transaction) String() string {
+	return Stringify(t)
+}
+
+// Parameters of a wallet send.
+type walletSendOptions struct {
+	Address string `url:"address"`
+	Amount  Amount `url:"amount"`
+	PIN     string `url:"pincode,omitempty"`
+}
+
+// Middleman used strictly for unmarshaling the result of wallet actions.
+type walletResultMiddleman struct {
+	Message *string `json:"message,omitempty"`
+	Address *string `json:"address,omitempty"`
+}
+
+// Get fetches the wallet of the authenticated account, including its
+// transactions of the last 30 days.
+func (s *WalletService) Get() (*Wallet, *Response, error) {
+	return s.GetContext(context.Background())
+}
+
+// GetContext is like Get but uses ctx to control its requests.
+func (s *WalletService) GetContext(ctx context.Context) (*Wallet, *Response, error) {
+	return s.get(ctx, "api/wallet/")
+}
+
+// Balance fetches the balance and receiving addresses of the wallet of the
+// authenticated account. Transactions are not included, which makes Balance
+// cheaper than Get.
+func (s *WalletService) Balance() (*Wallet, *Response, error) {
+	return s.BalanceContext(context.Background())
+}
+
+// BalanceContext is like Balance but uses ctx to control its requests.
+func (s *WalletService) BalanceContext(ctx context.Context) (*Wallet, *Response, error) {
+	return s.get(ctx, "api/wallet-balance/")
+}
+
+func (s *WalletService) get(ctx context.Context, u string) (*Wallet, *Response, error) {
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	wallet := new(Wallet)
+	respMiddleman := &ResponseData{Data: wallet}
+	resp, err := s.client.DoContext(ctx, req, respMiddleman)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return wallet, resp, err
+}
+
+// NewAddress creates a new receiving address for the wallet of the
+// authenticated account and returns it.
+func (s *WalletService) NewAddress() (string, *Response, error) {
+	return s.NewAddressContext(context.Background())
+}
+
+// NewAddressContext is like NewAddress but uses ctx to control its requests.
+func (s *WalletService) NewAddressContext(ctx context.Context) (string, *Response, error) {
+	result, resp, err := s.post(ctx, "api/wallet-addr/", nil)
+	if err != nil {
+		return "", resp, err
+	}
+
+	var addr string
+	if result.Address != nil {
+		addr = *result.Address
+	}
+	return addr, resp, err
+}
+
+// Send sends the provided amount of bitcoin from the wallet of the
+// authenticated account to address. The message returned by the API is passed
+// back to the caller. An error is returned without sending anything if amount
+// is not positive or is more precise than a satoshi.
+func (s *WalletService) Send(address string, amount Amount) (string, *Response, error) {
+	return s.SendContext(context.Background(), address, amount)
+}
+
+// SendContext is like Send but uses ctx to control its requests.
+func (s *WalletService) SendContext(ctx context.Context, address string, amount Amount) (string, *Response, error) {
+	return s.send(ctx, "api/wallet-send/",
+		&walletSendOptions{Address: address, Amount: amount})
+}
+
+// SendWithPIN sends the provided amount of bitcoin from the wallet of the
+// authenticated account to address, authorizing the transaction with the
+// account's PIN code. The message returned by the API is passed back to the
+// caller. amount is checked as by Send.
+func (s *WalletService) SendWithPIN(address string, amount Amount,
+	pin string) (string, *Response, error) {
+	return s.SendWithPINContext(context.Background(), address, amount, pin)
+}
+
+// SendWithPINContext is like SendWithPIN but uses ctx to control its requests.
+func (s *WalletService) SendWithPINContext(ctx context.Context, address string, amount Amount,
+	pin string) (string, *Response, error) {
+	return s.send(ctx, "api/wallet-send-pin/",
+		&walletSendOptions{Address: address, Amount: amount, PIN: pin})
+}
+
+func (s *WalletService) send(ctx context.Context, u string, opt *walletSendOptions) (string, *Response, error) {
+	n, err := opt.Amount.Satoshis()
+	if err != nil {
+		return "", nil, err
+	}
+	if n <= 0 {
+		return "", nil, fmt.Errorf("localbitcoins: cannot send %v BTC, the amount must be positive", opt.Amount)
+	}
+	opt.Amount = FromSatoshis(n)
+
+	result, resp, err := s.post(ctx, u, opt)
+	if err != nil {
+		return "", resp, err
+	}
+
+	var msg string
+	if result.Message != nil {
+		msg = *result.Message
+	}
+	return msg, resp, err
+}
+
+func (s *WalletService) post(ctx context.Context, u string, opt *walletSendOptions) (*walletResultMiddleman, *Response, error) {
+	req, err := s.client.NewFormRequest("POST", u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := new(walletResultMiddleman)
+	respMiddleman := &ResponseData{Data: result}
+	resp, err := s.client.DoContext(ctx, req, respMiddleman)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return result, resp, err
+}
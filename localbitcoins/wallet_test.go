@@ -0,0 +1,164 @@
+package localbitcoins
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWalletService_Get(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/wallet/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{
+      "data":{
+        "message":"OK",
+        "total":{"balance":"0.05000001","sendable":"0.05"},
+        "sent_transactions_30d":[
+          {"txid":"abc","amount":"0.01","description":"Sent","tx_type":1,"created_at":"2014-06-01T12:00:00Z"}
+        ],
+        "received_transactions_30d":[],
+        "receiving_address_count":1,
+        "receiving_address_list":[{"address":"1abc","received":"0.00000000"}]
+      }
+    }`)
+	})
+
+	wallet, _, err := client.Wallet.Get()
+	if err != nil {
+		t.Errorf("Wallet.Get returned error: %v", err)
+	}
+
+	tt := TransactionSend
+	created := time.Date(2014, 6, 1, 12, 0, 0, 0, time.UTC)
+	want := &Wallet{
+		Message: String("OK"),
+		Total: &WalletBalance{
+			Balance:  amountPtr("0.05000001"),
+			Sendable: amountPtr("0.05"),
+		},
+		SentTransactions: []*Transaction{
+			&Transaction{
+				TxID:        String("abc"),
+				Amount:      amountPtr("0.01"),
+				Description: String("Sent"),
+				Type:        &tt,
+				CreatedAt:   &created,
+			},
+		},
+		ReceivedTransactions:  []*Transaction{},
+		ReceivingAddressCount: Int(1),
+		ReceivingAddresses: []*ReceivingAddress{
+			&ReceivingAddress{Address: String("1abc"), Received: amountPtr("0.00000000")},
+		},
+	}
+	if !reflect.DeepEqual(wallet, want) {
+		t.Errorf("Wallet.Get returned %+v, want %+v", wallet, want)
+	}
+}
+
+func TestWalletService_Balance(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/wallet-balance/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data":{"total":{"balance":"1.5","sendable":"1.4"}}}`)
+	})
+
+	wallet, _, err := client.Wallet.Balance()
+	if err != nil {
+		t.Errorf("Wallet.Balance returned error: %v", err)
+	}
+
+	want := &Wallet{
+		Total: &WalletBalance{Balance: amountPtr("1.5"), Sendable: amountPtr("1.4")},
+	}
+	if !reflect.DeepEqual(wallet, want) {
+		t.Errorf("Wallet.Balance returned %+v, want %+v", wallet, want)
+	}
+}
+
+func TestWalletService_NewAddress(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/wallet-addr/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, `{"data":{"message":"OK!","address":"1new"}}`)
+	})
+
+	addr, _, err := client.Wallet.NewAddress()
+	if err != nil {
+		t.Errorf("Wallet.NewAddress returned error: %v", err)
+	}
+	if want := "1new"; addr != want {
+		t.Errorf("Wallet.NewAddress returned %q, want %q", addr, want)
+	}
+}
+
+func TestWalletService_Send(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/wallet-send/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testFormValues(t, r, values{"address": "1abc", "amount": "0.00012345"})
+		fmt.Fprint(w, `{"data":{"message":"Money is being sent"}}`)
+	})
+
+	msg, _, err := client.Wallet.Send("1abc", FromSatoshis(12345))
+	if err != nil {
+		t.Errorf("Wallet.Send returned error: %v", err)
+	}
+	if want := "Money is being sent"; msg != want {
+		t.Errorf("Wallet.Send returned %q, want %q", msg, want)
+	}
+}
+
+func TestWalletService_SendWithPIN(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/wallet-send-pin/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testFormValues(t, r, values{"address": "1abc", "amount": "1.00000000", "pincode": "1234"})
+		fmt.Fprint(w, `{"data":{"message":"Money is being sent"}}`)
+	})
+
+	_, _, err := client.Wallet.SendWithPIN("1abc", *amountPtr("1"), "1234")
+	if err != nil {
+		t.Errorf("Wallet.SendWithPIN returned error: %v", err)
+	}
+}
+
+func TestWalletService_Send_invalidAmount(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request to %v", r.URL.Path)
+	})
+
+	for _, amount := range []string{"0", "-0.1", "0.123456789"} {
+		if _, _, err := client.Wallet.Send("1abc", MustParseAmount(amount)); err == nil {
+			t.Errorf("Wallet.Send(%v) returned no error", amount)
+		}
+		if _, _, err := client.Wallet.SendWithPIN("1abc", MustParseAmount(amount), "1234"); err == nil {
+			t.Errorf("Wallet.SendWithPIN(%v) returned no error", amount)
+		}
+	}
+}
+
+func TestTransactionType_String(t *testing.T) {
+	if got, want := TransactionReceive.String(), "receive"; got != want {
+		t.Errorf("TransactionReceive.String() = %v, want %v", got, want)
+	}
+	if got, want := TransactionType(9).String(), "TransactionType(9)"; got != want {
+		t.Errorf("TransactionType(9).String() = %v, want %v", got, want)
+	}
+}
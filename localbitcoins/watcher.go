@@ -0,0 +1,350 @@
+package localbitcoins
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// EventType is the type of a state transition of a trade reported by a
+// Watcher.
+type EventType int
+
+// Event types reported by a Watcher.
+const (
+	// A contact was opened.
+	TradeOpened EventType = iota + 1
+
+	// The buyer marked a contact as paid.
+	PaymentMarked
+
+	// The bitcoin of a contact was released to the buyer.
+	Released
+
+	// A contact was disputed.
+	Disputed
+
+	// A contact was canceled.
+	Canceled
+
+	// A message was posted to a contact.
+	NewMessage
+)
+
+var eventTypeNames = map[EventType]string{
+	TradeOpened:   "TradeOpened",
+	PaymentMarked: "PaymentMarked",
+	Released:      "Released",
+	Disputed:      "Disputed",
+	Canceled:      "Canceled",
+	NewMessage:    "NewMessage",
+}
+
+func (t EventType) String() string {
+	if name, ok := eventTypeNames[t]; ok {
+		return name
+	}
+	return "Unknown"
+}
+
+// Event is a state transition of a trade reported by a Watcher.
+type Event struct {
+	Type EventType
+
+	// Contact the event is about, as of the poll that detected the event.
+	Contact *Contact
+
+	// Open escrow of the contact, if any, matched by reference code.
+	Escrow *Escrow
+
+	// Message that was posted, for NewMessage events.
+	Message *Message
+}
+
+// A Watcher periodically fetches the open contacts and escrows of the
+// authenticated account, keeps track of their state and reports the changes
+// between polls as events. Events are either passed to handlers registered
+// with Handle, by Run, or sent on a channel, by Watch.
+//
+// Contacts that are open when a Watcher first polls are taken as its initial
+// state and reported only if EmitExisting is set.
+type Watcher struct {
+	client *Client
+
+	// Interval between polls. If not positive, the Watcher polls every
+	// minute.
+	Interval time.Duration
+
+	// Whether to fetch the messages of open contacts on every poll to report
+	// NewMessage events. This takes one request per open contact.
+	Messages bool
+
+	// Whether to report the contacts that are open when the Watcher first
+	// polls, and their messages, as new.
+	EmitExisting bool
+
+	// OnError, if set, is called by Run with the errors of individual polls.
+	OnError func(error)
+
+	mu       sync.Mutex
+	handlers map[EventType][]func(*Event)
+
+	// pollMu serializes polls and guards the state of the last poll, so that
+	// Handle doesn't wait for the requests of a poll in progress.
+	pollMu sync.Mutex
+
+	// State of the last poll. contacts is nil before the first poll.
+	contacts map[int]*Contact
+	escrows  map[string]*Escrow
+	messages map[int]int // number of messages seen per contact
+}
+
+// NewWatcher returns a new Watcher that uses client to poll every interval. If
+// interval is not positive, the Watcher polls every minute.
+func NewWatcher(client *Client, interval time.Duration) *Watcher {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	return &Watcher{
+		client:   client,
+		Interval: interval,
+		handlers: make(map[EventType][]func(*Event)),
+	}
+}
+
+// Handle registers h to be called by Run with every event of type t.
+func (w *Watcher) Handle(t EventType, h func(*Event)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.handlers[t] = append(w.handlers[t], h)
+}
+
+// Run polls every Interval until ctx is done, and passes every event to the
+// handlers registered for its type, in the order the events were detected.
+// Errors of individual polls are passed to OnError, if set, and do not stop
+// Run. Run returns the error of ctx.
+func (w *Watcher) Run(ctx context.Context) error {
+	w.loop(ctx, func(e *Event) bool {
+		w.mu.Lock()
+		handlers := w.handlers[e.Type]
+		w.mu.Unlock()
+
+		for _, h := range handlers {
+			h(e)
+		}
+		return true
+	}, func(err error) {
+		if w.OnError != nil {
+			w.OnError(err)
+		}
+	})
+	return ctx.Err()
+}
+
+// Watch polls every Interval until ctx is done, and sends every event on the
+// returned event channel, in the order the events were detected.
+//
+// Errors of individual polls are sent on the returned error channel, which is
+// buffered; errors are dropped rather than stall polling if it is not read.
+// Both channels are closed once ctx is done.
+func (w *Watcher) Watch(ctx context.Context) (<-chan *Event, <-chan error) {
+	events := make(chan *Event)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(events)
+		defer close(errs)
+
+		w.loop(ctx, func(e *Event) bool {
+			select {
+			case events <- e:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}, func(err error) {
+			select {
+			case errs <- err:
+			default:
+			}
+		})
+	}()
+
+	return events, errs
+}
+
+// Polls every Interval until ctx is done, passing events to emit until it
+// returns false and errors to fail.
+func (w *Watcher) loop(ctx context.Context, emit func(*Event) bool, fail func(error)) {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		events, err := w.Poll(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		for _, e := range events {
+			if !emit(e) {
+				return
+			}
+		}
+		if err != nil {
+			fail(err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Poll fetches the open contacts and escrows of the authenticated account once
+// and returns the events since the previous poll. If Poll fails part way, it
+// returns the events detected so far along with the error; the changes it
+// could not process are reported by a later poll.
+//
+// Poll is called by Run and Watch, but may also be called directly to drive a
+// Watcher without its own polling loop.
+func (w *Watcher) Poll(ctx context.Context) ([]*Event, error) {
+	w.pollMu.Lock()
+	defer w.pollMu.Unlock()
+
+	contacts, err := w.client.Dashboard.OpenIterator().AllContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	escrowList, _, err := w.client.Escrows.ListContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	escrows := make(map[string]*Escrow, len(escrowList))
+	for _, e := range escrowList {
+		if e.ReferenceCode != nil {
+			escrows[*e.ReferenceCode] = e
+		}
+	}
+
+	initial := w.contacts == nil
+	if initial {
+		w.contacts = make(map[int]*Contact)
+		w.messages = make(map[int]int)
+	}
+	emitNew := !initial || w.EmitExisting
+
+	var events []*Event
+	emit := func(t EventType, c *Contact, escrows map[string]*Escrow) *Event {
+		e := &Event{Type: t, Contact: c}
+		if c.ReferenceCode != nil {
+			e.Escrow = escrows[*c.ReferenceCode]
+		}
+		events = append(events, e)
+		return e
+	}
+
+	open := make(map[int]*Contact, len(contacts))
+	opened := make(map[int]bool)
+	for _, c := range contacts {
+		if c.ID == nil {
+			continue
+		}
+		open[*c.ID] = c
+
+		prev, ok := w.contacts[*c.ID]
+		if !ok {
+			if !emitNew {
+				continue
+			}
+			emit(TradeOpened, c, escrows)
+			opened[*c.ID] = true
+			prev = new(Contact)
+		}
+		for _, t := range contactTransitions(prev, c) {
+			emit(t, c, escrows)
+		}
+	}
+
+	// Contacts that are no longer open were released or canceled, and are
+	// fetched once more to tell which. A contact that cannot be fetched is
+	// kept, so that the next poll tries again.
+	var firstErr error
+	var closed []int
+	for id := range w.contacts {
+		if open[id] == nil {
+			closed = append(closed, id)
+		}
+	}
+	sort.Ints(closed)
+	for _, id := range closed {
+		c, _, err := w.client.Contacts.GetContext(ctx, id)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			open[id] = w.contacts[id]
+			continue
+		}
+		for _, t := range contactTransitions(w.contacts[id], c) {
+			// the escrows of closed contacts are gone, so use the last ones
+			emit(t, c, w.escrows)
+		}
+		delete(w.messages, id)
+	}
+
+	if w.Messages {
+		for _, c := range contacts {
+			if c.ID == nil {
+				continue
+			}
+			messages, _, err := w.client.Messages.ListContext(ctx, *c.ID)
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				continue
+			}
+
+			// the messages of a newly opened contact are all new, but those of
+			// other contacts seen for the first time are taken as they are
+			seen, ok := w.messages[*c.ID]
+			if (!ok && !opened[*c.ID]) || seen > len(messages) {
+				seen = len(messages)
+			}
+			for _, m := range messages[seen:] {
+				emit(NewMessage, c, escrows).Message = m
+			}
+			w.messages[*c.ID] = len(messages)
+		}
+	}
+
+	w.contacts = open
+	w.escrows = escrows
+	return events, firstErr
+}
+
+// Returns the types of the events that happened to a contact between the
+// states prev and cur.
+func contactTransitions(prev, cur *Contact) []EventType {
+	var types []EventType
+	if prev.PaymentCompletedAt == nil && cur.PaymentCompletedAt != nil {
+		types = append(types, PaymentMarked)
+	}
+	if prev.DisputedAt == nil && cur.DisputedAt != nil {
+		types = append(types, Disputed)
+	}
+	if prev.ReleasedAt == nil && cur.ReleasedAt != nil {
+		types = append(types, Released)
+	}
+	if prev.CanceledAt == nil && cur.CanceledAt != nil {
+		types = append(types, Canceled)
+	}
+	return types
+}
@@ -0,0 +1,362 @@
+package localbitcoins
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Serves the open contacts, escrows, closed contacts and messages of a fake
+// account, which tests change between polls.
+type watcherServer struct {
+	mu       sync.Mutex
+	open     string            // JSON array of contacts
+	escrows  string            // JSON array of escrows
+	contacts map[string]string // JSON contact by ID
+	messages map[string]string // JSON array of messages by contact ID
+}
+
+func (s *watcherServer) set(f func(s *watcherServer)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f(s)
+}
+
+func newWatcherServer() *watcherServer {
+	s := &watcherServer{
+		open:     "[]",
+		escrows:  "[]",
+		contacts: make(map[string]string),
+		messages: make(map[string]string),
+	}
+
+	mux.HandleFunc("/api/dashboard/", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		fmt.Fprintf(w, `{"data":{"contact_list":%v}}`, s.open)
+	})
+	mux.HandleFunc("/api/escrows/", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		fmt.Fprintf(w, `{"data":{"escrow_list":%v}}`, s.escrows)
+	})
+	mux.HandleFunc("/api/contact_info/", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		id := r.URL.Path[len("/api/contact_info/") : len(r.URL.Path)-1]
+		c, ok := s.contacts[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":{"message":"Not found","error_code":12}}`)
+			return
+		}
+		fmt.Fprintf(w, `{"data":%v}`, c)
+	})
+	mux.HandleFunc("/api/contact_messages/", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		id := r.URL.Path[len("/api/contact_messages/") : len(r.URL.Path)-1]
+		m, ok := s.messages[id]
+		if !ok {
+			m = "[]"
+		}
+		fmt.Fprintf(w, `{"data":{"message_list":%v}}`, m)
+	})
+
+	return s
+}
+
+// Returns the types and contact IDs of events, such as "TradeOpened 2".
+func describeEvents(events []*Event) []string {
+	var desc []string
+	for _, e := range events {
+		d := fmt.Sprintf("%v %v", e.Type, *e.Contact.ID)
+		if e.Escrow != nil {
+			d += " escrow " + *e.Escrow.ReferenceCode
+		}
+		if e.Message != nil {
+			d += " " + *e.Message.Message
+		}
+		desc = append(desc, d)
+	}
+	return desc
+}
+
+func TestWatcher_Poll(t *testing.T) {
+	setup()
+	defer teardown()
+
+	s := newWatcherServer()
+	s.set(func(s *watcherServer) {
+		s.open = `[{"data":{"contact_id":1,"reference_code":"L1"}}]`
+		s.escrows = `[{"data":{"reference_code":"L1"}}]`
+	})
+
+	w := NewWatcher(client, time.Minute)
+	polls := []struct {
+		update func(s *watcherServer)
+		want   []string
+	}{
+		// initial state is not reported
+		{func(s *watcherServer) {}, nil},
+		{func(s *watcherServer) {
+			s.open = `[
+        {"data":{"contact_id":1,"reference_code":"L1","payment_completed_at":"2014-01-01T00:00:00Z"}},
+        {"data":{"contact_id":2,"reference_code":"L2"}}
+      ]`
+		}, []string{"PaymentMarked 1 escrow L1", "TradeOpened 2"}},
+		{func(s *watcherServer) {}, nil},
+		{func(s *watcherServer) {
+			s.open = `[{"data":{"contact_id":2,"reference_code":"L2","disputed_at":"2014-01-02T00:00:00Z"}}]`
+			s.escrows = `[]`
+			s.contacts["1"] = `{"contact_id":1,"reference_code":"L1",
+        "payment_completed_at":"2014-01-01T00:00:00Z","released_at":"2014-01-02T00:00:00Z"}`
+		}, []string{"Disputed 2", "Released 1 escrow L1"}},
+		{func(s *watcherServer) {
+			s.open = `[]`
+			s.contacts["2"] = `{"contact_id":2,"disputed_at":"2014-01-02T00:00:00Z",
+        "canceled_at":"2014-01-03T00:00:00Z"}`
+		}, []string{"Canceled 2"}},
+	}
+
+	for i, p := range polls {
+		s.set(p.update)
+		events, err := w.Poll(context.Background())
+		if err != nil {
+			t.Errorf("poll %v: Poll returned error: %v", i, err)
+		}
+		if got := describeEvents(events); !reflect.DeepEqual(got, p.want) {
+			t.Errorf("poll %v: Poll returned %v, want %v", i, got, p.want)
+		}
+	}
+}
+
+func TestWatcher_Poll_emitExisting(t *testing.T) {
+	setup()
+	defer teardown()
+
+	s := newWatcherServer()
+	s.set(func(s *watcherServer) {
+		s.open = `[{"data":{"contact_id":1,"payment_completed_at":"2014-01-01T00:00:00Z"}}]`
+	})
+
+	w := NewWatcher(client, time.Minute)
+	w.EmitExisting = true
+
+	events, err := w.Poll(context.Background())
+	if err != nil {
+		t.Errorf("Poll returned error: %v", err)
+	}
+	want := []string{"TradeOpened 1", "PaymentMarked 1"}
+	if got := describeEvents(events); !reflect.DeepEqual(got, want) {
+		t.Errorf("Poll returned %v, want %v", got, want)
+	}
+}
+
+func TestWatcher_Poll_closedContactError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	s := newWatcherServer()
+	s.set(func(s *watcherServer) {
+		s.open = `[{"data":{"contact_id":1}}]`
+	})
+
+	w := NewWatcher(client, time.Minute)
+	if _, err := w.Poll(context.Background()); err != nil {
+		t.Fatalf("Poll returned error: %v", err)
+	}
+
+	// contact 1 closes but cannot be fetched yet
+	s.set(func(s *watcherServer) { s.open = `[]` })
+	events, err := w.Poll(context.Background())
+	if err == nil {
+		t.Errorf("Poll returned no error, want one")
+	}
+	if len(events) != 0 {
+		t.Errorf("Poll returned %v, want no events", describeEvents(events))
+	}
+
+	s.set(func(s *watcherServer) {
+		s.contacts["1"] = `{"contact_id":1,"canceled_at":"2014-01-03T00:00:00Z"}`
+	})
+	events, err = w.Poll(context.Background())
+	if err != nil {
+		t.Errorf("Poll returned error: %v", err)
+	}
+	want := []string{"Canceled 1"}
+	if got := describeEvents(events); !reflect.DeepEqual(got, want) {
+		t.Errorf("Poll returned %v, want %v", got, want)
+	}
+}
+
+func TestWatcher_Poll_messages(t *testing.T) {
+	setup()
+	defer teardown()
+
+	s := newWatcherServer()
+	s.set(func(s *watcherServer) {
+		s.open = `[{"data":{"contact_id":1}}]`
+		s.messages["1"] = `[{"msg":"old"}]`
+	})
+
+	w := NewWatcher(client, time.Minute)
+	w.Messages = true
+	if _, err := w.Poll(context.Background()); err != nil {
+		t.Fatalf("Poll returned error: %v", err)
+	}
+
+	s.set(func(s *watcherServer) {
+		s.open = `[{"data":{"contact_id":1}},{"data":{"contact_id":2}}]`
+		s.messages["1"] = `[{"msg":"old"},{"msg":"paid?"}]`
+		s.messages["2"] = `[{"msg":"hi"}]`
+	})
+	events, err := w.Poll(context.Background())
+	if err != nil {
+		t.Errorf("Poll returned error: %v", err)
+	}
+	want := []string{"TradeOpened 2", "NewMessage 1 paid?", "NewMessage 2 hi"}
+	if got := describeEvents(events); !reflect.DeepEqual(got, want) {
+		t.Errorf("Poll returned %v, want %v", got, want)
+	}
+}
+
+func TestWatcher_Run(t *testing.T) {
+	setup()
+	defer teardown()
+
+	s := newWatcherServer()
+	w := NewWatcher(client, time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var got []*Event
+	w.Handle(TradeOpened, func(e *Event) {
+		got = append(got, e)
+		cancel()
+	})
+	w.Handle(Canceled, func(e *Event) {
+		t.Errorf("Unexpected Canceled event for contact %v", *e.Contact.ID)
+	})
+
+	// the first poll takes the empty dashboard as initial state
+	w.Poll(ctx)
+	s.set(func(s *watcherServer) { s.open = `[{"data":{"contact_id":3}}]` })
+
+	if err := w.Run(ctx); err != context.Canceled {
+		t.Errorf("Run returned %v, want %v", err, context.Canceled)
+	}
+	if want := []string{"TradeOpened 3"}; !reflect.DeepEqual(describeEvents(got), want) {
+		t.Errorf("Run handled %v, want %v", describeEvents(got), want)
+	}
+}
+
+func TestWatcher_Watch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	s := newWatcherServer()
+	w := NewWatcher(client, time.Millisecond)
+	w.EmitExisting = true
+	s.set(func(s *watcherServer) { s.open = `[{"data":{"contact_id":4}}]` })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, _ := w.Watch(ctx)
+	select {
+	case e := <-events:
+		if e.Type != TradeOpened || *e.Contact.ID != 4 {
+			t.Errorf("Watch sent %v, want TradeOpened 4", describeEvents([]*Event{e}))
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Watch timed out")
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestWatcher_Handle_duringPoll(t *testing.T) {
+	setup()
+	defer teardown()
+
+	polling := make(chan bool)
+	release := make(chan bool)
+	mux.HandleFunc("/api/dashboard/", func(w http.ResponseWriter, r *http.Request) {
+		polling <- true
+		<-release
+		fmt.Fprint(w, `{"data":{"contact_list":[]}}`)
+	})
+	mux.HandleFunc("/api/escrows/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"escrow_list":[]}}`)
+	})
+
+	w := NewWatcher(client, time.Millisecond)
+	done := make(chan bool)
+	go func() {
+		w.Poll(context.Background())
+		close(done)
+	}()
+	<-polling
+
+	handled := make(chan bool)
+	go func() {
+		w.Handle(TradeOpened, func(*Event) {})
+		close(handled)
+	}()
+	select {
+	case <-handled:
+	case <-time.After(time.Second):
+		t.Errorf("Handle blocked while a poll was in progress")
+	}
+
+	close(release)
+	<-done
+}
+
+func TestWatcher_zeroInterval(t *testing.T) {
+	setup()
+	defer teardown()
+
+	s := newWatcherServer()
+	s.set(func(s *watcherServer) { s.open = `[{"data":{"contact_id":5}}]` })
+
+	if w := NewWatcher(client, 0); w.Interval <= 0 {
+		t.Errorf("NewWatcher set Interval %v, want a positive default", w.Interval)
+	}
+
+	// a Watcher whose Interval is unset polls at the default interval
+	w := &Watcher{client: client, EmitExisting: true}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, _ := w.Watch(ctx)
+	select {
+	case e := <-events:
+		if e.Type != TradeOpened || *e.Contact.ID != 5 {
+			t.Errorf("Watch sent %v, want TradeOpened 5", describeEvents([]*Event{e}))
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Watch timed out")
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestEventType_String(t *testing.T) {
+	if got, want := Released.String(), "Released"; got != want {
+		t.Errorf("Released.String() = %q, want %q", got, want)
+	}
+	if got, want := EventType(0).String(), "Unknown"; got != want {
+		t.Errorf("EventType(0).String() = %q, want %q", got, want)
+	}
+}